@@ -25,10 +25,41 @@ func (resp fsResponse) check(reqID, domain, apiName string) error {
 	if resp.Code == 0 {
 		return nil
 	}
-	if reqID != "" {
-		return fmt.Errorf(_fmtErrResp, domain, apiName, reqID, resp.Code, resp.Msg)
+	return &APIError{
+		Domain:    domain,
+		API:       apiName,
+		RequestID: reqID,
+		Code:      resp.Code,
+		Msg:       resp.Msg,
 	}
-	return fmt.Errorf(_fmtErrRespNoID, domain, apiName, resp.Code, resp.Msg)
+}
+
+// APIError 开放平台接口返回的错误（错误码非 0）
+//  可通过 errors.As 获取
+//  错误码说明: https://open.feishu.cn/document/server-docs/getting-started/server-error-codes
+type APIError struct {
+	Domain    string // 接口所属的业务域
+	API       string // 接口名称
+	RequestID string // 响应头 X-Request-ID, 可能为空
+	Code      int    // 错误码
+	Msg       string // 错误描述
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf(_fmtErrResp, e.Domain, e.API, e.RequestID, e.Code, e.Msg)
+	}
+	return fmt.Sprintf(_fmtErrRespNoID, e.Domain, e.API, e.Code, e.Msg)
+}
+
+// IsRateLimited 是否触发了频率限制
+func (e *APIError) IsRateLimited() bool {
+	switch e.Code {
+	case 99991400, // 应用频率限制
+		230020: // 消息与群组: 操作触发频率限制
+		return true
+	}
+	return false
 }
 
 const (
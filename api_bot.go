@@ -0,0 +1,75 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+)
+
+// 名称: [机器人] 获取机器人信息
+// Func: [api_bot.go] GetBotInfo
+//
+// 描述: 获取机器人的基本信息
+// Info: 需要开启机器人能力
+// Info: BotInfo.OpenID 可用于 StripBotMention 识别消息中是否 @ 了机器人
+//
+// Doc: https://open.feishu.cn/document/client-docs/bot-v3/obtain-bot-info
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/bot/v3/info
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{TenantAccessToken}}
+//
+type botInfoResponse struct {
+	fsResponse
+	Bot BotInfo `json:"bot"`
+}
+
+type BotInfo struct {
+	ActivateStatus int      `json:"activate_status"` // 机器人激活状态: 0 初始化，租户待安装; 1 租户停用; 2 租户启用; 3 安装后待启用; 4 升级待启用; 5 license过期停用; 6 Lark套餐到期或降级停用
+	AppName        string   `json:"app_name"`        // 应用名称
+	AvatarURL      string   `json:"avatar_url"`      // 应用图像地址
+	IPWhiteList    []string `json:"ip_white_list"`   // 应用的 IP 白名单地址
+	OpenID         string   `json:"open_id"`         // 机器人的 open_id
+}
+
+func (a *app) GetBotInfo() (BotInfo, error) {
+	return a.GetBotInfoWithContext(context.Background())
+}
+
+func (a *app) GetBotInfoWithContext(ctx context.Context) (BotInfo, error) {
+	apiDomain := "机器人"
+	apiName := "获取机器人信息"
+	urlSuffix := "/open-apis/bot/v3/info"
+
+	if !a.isSupported(true, true) {
+		return BotInfo{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getTenantAccessTokenWithContext(ctx); err != nil {
+		return BotInfo{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._getWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return BotInfo{}, err
+	}
+
+	resp := new(botInfoResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return BotInfo{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return BotInfo{}, err
+	}
+
+	return resp.Bot, nil
+}
@@ -0,0 +1,15 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_GetBotInfo(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	botInfo, err := fsApp.GetBotInfo()
+	requireNil(t, err)
+
+	logIndent(t, botInfo)
+}
@@ -3,7 +3,10 @@ package feishu
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 // 名称: [消息与群组] 发送消息
@@ -40,6 +43,9 @@ type sendMessageRequest struct {
 
 	// 消息类型 包括：text、post、image、file、audio、media、sticker、interactive、share_chat、share_user等
 	MsgType string `json:"msg_type"`
+
+	// 仅回复消息时有效: 是否以话题形式回复。若要回复的消息已经是话题形式的消息，则默认以话题形式进行回复
+	ReplyInThread bool `json:"reply_in_thread,omitempty"`
 }
 
 type sendMessageResponse struct {
@@ -61,6 +67,7 @@ type MessageDetail struct {
 	Body           MessageBody `json:"body"`             // 消息内容
 	Mentions       []Mention   `json:"mentions"`         // 被@的用户或机器人的id列表
 	UpperMessageID string      `json:"upper_message_id"` // 合并转发消息中，上一层级的消息id message_id
+	ThreadID       string      `json:"thread_id"`        // 消息所属的话题 ID（不返回说明该消息非话题消息）
 }
 
 type Sender struct {
@@ -93,6 +100,7 @@ type MessageReceiver struct {
 type Message struct {
 	msgType string
 	content interface{}
+	err     error // 构造消息时产生的错误, 发送前返回
 }
 
 func NewMessageText(content string) *Message {
@@ -111,11 +119,11 @@ func NewMessagePost(p Post, more ...Post) *Message {
 	for _, fn := range more {
 		i18nPosts = append(i18nPosts, fn())
 	}
-	post := make(map[string]interface{}, 3)
+	post := make(map[string]postBody, 3)
 	for _, p := range i18nPosts {
-		post[p.lang] = map[string]interface{}{
-			"title":   p.title,
-			"content": p.elements,
+		post[p.lang] = postBody{
+			Title:   p.title,
+			Content: p.elements,
 		}
 	}
 
@@ -143,31 +151,76 @@ func NewMessageCard(bgColor CardTitleBgColor, cfg CardConfig, c Card, more ...Ca
 	}
 
 	i18nTitle := make(map[string]string, 3)
-	i18nElements := make(map[string]interface{}, 3)
+	i18nElements := make(map[string][]interface{}, 3)
+	var err error
 	for _, c := range cards {
 		i18nTitle[c.lang] = c.title
 		i18nElements[c.lang] = c.elements
+		if err == nil {
+			err = cardElementError(c.elements...)
+		}
 	}
 
-	sub := map[string]interface{}{
-		"header":        buildCardHeader(bgColor, i18nTitle),
-		"i18n_elements": i18nElements,
+	sub := &msgCard{
+		Header:       buildCardHeader(bgColor, i18nTitle),
+		I18nElements: i18nElements,
 	}
 
 	if cfg != nil {
 		_cfg := cfg()
-		if _cfg.mCfg != nil {
-			sub["config"] = _cfg.mCfg
-		}
-		if _cfg.mCardLink != nil {
-			sub["card_link"] = _cfg.mCardLink
-		}
+		sub.Config = _cfg.mCfg
+		sub.CardLink = _cfg.mCardLink
 	}
 
 	return &Message{
 		msgType: "interactive",
 		content: sub,
+		err:     err,
+	}
+}
+
+type cardTemplate struct {
+	Type string           `json:"type"` // 固定为 template
+	Data cardTemplateData `json:"data"`
+}
+
+type cardTemplateData struct {
+	TemplateID          string      `json:"template_id"`                     // 卡片模板 ID
+	TemplateVersionName string      `json:"template_version_name,omitempty"` // 卡片模板版本号, 为空时使用最新发布的版本
+	TemplateVariable    interface{} `json:"template_variable,omitempty"`     // 卡片模板中的变量
+}
+
+// NewMessageCardTemplate 使用卡片搭建工具中的卡片模板构造卡片消息
+//  version: 模板版本号, 如 1.0.0, 为空时使用最新发布的版本
+//  variables: 模板变量, 仅支持 map[string]T 或 struct（及其指针）, 按 JSON 序列化后的字段名对应变量名, 可为 nil
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/send-feishu-card
+func NewMessageCardTemplate(templateID, version string, variables interface{}) *Message {
+	msg := &Message{
+		msgType: "interactive",
+		content: cardTemplate{
+			Type: "template",
+			Data: cardTemplateData{
+				TemplateID:          templateID,
+				TemplateVersionName: version,
+				TemplateVariable:    variables,
+			},
+		},
 	}
+
+	if templateID == "" {
+		msg.err = errors.New("card template: empty template id")
+		return msg
+	}
+	if variables != nil {
+		rv := reflect.Indirect(reflect.ValueOf(variables))
+		switch {
+		case rv.Kind() == reflect.Struct:
+		case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		default:
+			msg.err = fmt.Errorf("card template: variables must be a map with string keys or a struct, got %T", variables)
+		}
+	}
+	return msg
 }
 
 func NewMessageShareChat(chatID string) *Message {
@@ -225,29 +278,190 @@ func NewMessageSticker(fileKey string) *Message {
 	}
 }
 
-func (a *app) SendMessage(receiver MessageReceiver, msg *Message) (MessageDetail, error) {
-	return a.SendMessageWithContext(context.Background(), receiver, msg)
+const (
+	_msgTextMaxSize = 150 << 10 // 文本消息请求体最大不能超过150KB
+	_msgCardMaxSize = 30 << 10  // 卡片及富文本消息请求体最大不能超过30KB
+)
+
+// MessageTooLargeError 消息请求体超出大小限制
+type MessageTooLargeError struct {
+	MsgType string // 消息类型
+	Size    int    // 请求体大小（字节）
+	Limit   int    // 该消息类型的请求体大小上限（字节）
+}
+
+func (e *MessageTooLargeError) Error() string {
+	return fmt.Sprintf("message(%s) too large: %d bytes (max: %d)", e.MsgType, e.Size, e.Limit)
+}
+
+func messageSizeLimit(msgType string) int {
+	switch msgType {
+	case "post", "interactive":
+		return _msgCardMaxSize
+	default:
+		return _msgTextMaxSize
+	}
+}
+
+func (msg *Message) marshalContent() (string, error) {
+	if msg.err != nil {
+		return "", msg.err
+	}
+	bs, err := json.Marshal(msg.content)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// checkSize 校验序列化后的请求体大小
+func (req *sendMessageRequest) checkSize() error {
+	bs, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if limit := messageSizeLimit(req.MsgType); len(bs) > limit {
+		return &MessageTooLargeError{MsgType: req.MsgType, Size: len(bs), Limit: limit}
+	}
+	return nil
+}
+
+type sendMessageOption struct {
+	splitText        bool
+	overflowFilename string
+	validate         bool
 }
 
-func (a *app) SendMessageWithContext(ctx context.Context, receiver MessageReceiver, msg *Message) (MessageDetail, error) {
+type SendMessageOption func(*sendMessageOption)
+
+// WithSendMessageSplitText 文本消息超出大小限制时, 按行拆分为多条消息依次发送
+//  单行超出限制时按字符拆分
+//  SendMessage 返回第一条消息的 MessageDetail
+func WithSendMessageSplitText() SendMessageOption {
+	return func(opt *sendMessageOption) {
+		opt.splitText = true
+		opt.overflowFilename = ""
+	}
+}
+
+// WithSendMessageOverflowFile 文本消息超出大小限制时, 按行截取能发送的部分作为文本消息,
+// 剩余部分作为文件(filename)上传后再发送文件消息
+//  剩余部分超过30MB时不发送并返回 *MessageTooLargeError
+//  SendMessage 返回文本消息的 MessageDetail
+func WithSendMessageOverflowFile(filename string) SendMessageOption {
+	return func(opt *sendMessageOption) {
+		opt.splitText = false
+		opt.overflowFilename = filename
+	}
+}
+
+// WithSendMessageValidate 发送前使用 Message.Validate 校验消息, 校验失败时不发送并返回 *MessageValidationError
+//  请求体大小仍按 SendMessage 的规则处理, 因此可与 WithSendMessageSplitText, WithSendMessageOverflowFile 同时使用
+func WithSendMessageValidate() SendMessageOption {
+	return func(opt *sendMessageOption) {
+		opt.validate = true
+	}
+}
+
+// SendMessage 发送消息
+//  发送前会校验序列化后的请求体大小, 超出限制时返回 *MessageTooLargeError
+//  通过 WithSendMessageValidate 可在发送前校验卡片等消息的结构
+//  文本消息可通过 WithSendMessageSplitText, WithSendMessageOverflowFile 自动处理超出限制的内容
+func (a *app) SendMessage(receiver MessageReceiver, msg *Message, opts ...SendMessageOption) (MessageDetail, error) {
+	return a.SendMessageWithContext(context.Background(), receiver, msg, opts...)
+}
+
+func (a *app) SendMessageWithContext(ctx context.Context, receiver MessageReceiver, msg *Message, opts ...SendMessageOption) (MessageDetail, error) {
 	apiDomain := "消息与群组"
 	apiName := "发送消息"
-	urlSuffix := "/open-apis/im/v1/messages"
 
 	if !a.isSupported(true, true) {
 		return MessageDetail{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
 	}
 
+	sendOpt := new(sendMessageOption)
+	for _, fn := range opts {
+		if fn == nil {
+			continue
+		}
+		fn(sendOpt)
+	}
+	if sendOpt.validate {
+		if err := msg.validate(false); err != nil {
+			return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+		}
+	}
+
 	data := &sendMessageRequest{
 		ReceiveID: receiver.ID,
 		Content:   "",
 		MsgType:   msg.msgType,
 	}
-	if bs, err := json.Marshal(msg.content); err != nil {
+	if content, err := msg.marshalContent(); err != nil {
 		return MessageDetail{}, err
 	} else {
-		data.Content = string(bs)
+		data.Content = content
+	}
+
+	err := data.checkSize()
+	if err == nil {
+		return a.sendMessageWithContext(ctx, receiver.IDType, data)
+	}
+	var errTooLarge *MessageTooLargeError
+	if !errors.As(err, &errTooLarge) || msg.msgType != "text" || (!sendOpt.splitText && sendOpt.overflowFilename == "") {
+		return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	text := msg.content.(map[string]string)["text"]
+	// 首段文本不会超过 _msgTextMaxSize, 剩余部分必然超出文件大小限制时无需拆分
+	if sendOpt.overflowFilename != "" && len(text)-_msgTextMaxSize > _fileMaxSize {
+		err = &MessageTooLargeError{MsgType: "file", Size: len(text) - _msgTextMaxSize, Limit: _fileMaxSize}
+		return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+	chunks, err := splitMessageText(data.ReceiveID, text)
+	if err != nil {
+		return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	if sendOpt.overflowFilename != "" {
+		// 剩余部分取自原文本, 按字符拆分的行不会被插入换行
+		rest := strings.TrimPrefix(text[len(chunks[0]):], "\n")
+		if len(rest) > _fileMaxSize {
+			err = &MessageTooLargeError{MsgType: "file", Size: len(rest), Limit: _fileMaxSize}
+			return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+		}
+		detail, err := a.SendMessageWithContext(ctx, receiver, NewMessageText(chunks[0]))
+		if err != nil {
+			return MessageDetail{}, err
+		}
+		fileKey, err := a.UploadFileWithContext(ctx, FileTypeStream, WithUploadFileViaReader(sendOpt.overflowFilename, strings.NewReader(rest)))
+		if err != nil {
+			return detail, err
+		}
+		if _, err = a.SendMessageWithContext(ctx, receiver, NewMessageFile(fileKey)); err != nil {
+			return detail, err
+		}
+		return detail, nil
+	}
+
+	var first MessageDetail
+	for i, chunk := range chunks {
+		detail, err := a.SendMessageWithContext(ctx, receiver, NewMessageText(chunk))
+		if err != nil {
+			return first, err
+		}
+		if i == 0 {
+			first = detail
+		}
 	}
+	return first, nil
+}
+
+func (a *app) sendMessageWithContext(ctx context.Context, receiveIDType IDType, data *sendMessageRequest) (MessageDetail, error) {
+	apiDomain := "消息与群组"
+	apiName := "发送消息"
+	urlSuffix := "/open-apis/im/v1/messages"
+
 	header := map[string]string{
 		"Content-Type":  "application/json; charset=utf-8",
 		"Authorization": "Bearer ",
@@ -258,7 +472,7 @@ func (a *app) SendMessageWithContext(ctx context.Context, receiver MessageReceiv
 		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
 	}
 	doOpts := a.buildOpts(apiDomain, apiName, header,
-		withDoQueryKV("receive_id_type", string(receiver.IDType)),
+		withDoQueryKV("receive_id_type", string(receiveIDType)),
 	)
 	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
 	if err != nil {
@@ -275,7 +489,77 @@ func (a *app) SendMessageWithContext(ctx context.Context, receiver MessageReceiv
 	}
 
 	return resp.Data, nil
+}
+
+// splitMessageText 将文本按行拆分, 使每一段作为文本消息发送时不超过请求体大小限制
+//  单行超出限制时按字符拆分
+func splitMessageText(receiveID, text string) ([]string, error) {
+	base := &sendMessageRequest{ReceiveID: receiveID, MsgType: "text"}
+	if content, err := NewMessageText("").marshalContent(); err != nil {
+		return nil, err
+	} else {
+		base.Content = content
+	}
+	bs, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	budget := _msgTextMaxSize - len(bs)
+	if budget <= 0 {
+		return nil, &MessageTooLargeError{MsgType: "text", Size: len(bs), Limit: _msgTextMaxSize}
+	}
 
+	var (
+		chunks  = make([]string, 0, 4)
+		cur     strings.Builder
+		curSize int
+		started bool // 当前段落是否已有内容（可能是空行）
+		nlSize  = doubleEscapedLen("\n")
+	)
+	flush := func() {
+		chunks = append(chunks, cur.String())
+		cur.Reset()
+		curSize, started = 0, false
+	}
+	for _, line := range strings.Split(text, "\n") {
+		lineSize := doubleEscapedLen(line)
+		if started {
+			if curSize+nlSize+lineSize <= budget {
+				cur.WriteString("\n")
+				cur.WriteString(line)
+				curSize += nlSize + lineSize
+				continue
+			}
+			flush()
+		}
+		if lineSize <= budget {
+			cur.WriteString(line)
+			curSize, started = lineSize, true
+			continue
+		}
+		// 单行超出限制, 按字符拆分
+		for _, r := range line {
+			rs := string(r)
+			size := doubleEscapedLen(rs)
+			if started && curSize+size > budget {
+				flush()
+			}
+			cur.WriteString(rs)
+			curSize, started = curSize+size, true
+		}
+	}
+	if started {
+		flush()
+	}
+	return chunks, nil
+}
+
+// doubleEscapedLen 字符串经过两次 JSON 转义后的长度
+//  消息内容先序列化为 JSON 字符串, 再作为请求体的字段再次序列化
+func doubleEscapedLen(s string) int {
+	bs, _ := json.Marshal(s)
+	bs, _ = json.Marshal(string(bs[1 : len(bs)-1]))
+	return len(bs) - 2
 }
 
 // 名称: [消息与群组] 回复消息
@@ -298,11 +582,26 @@ func (a *app) SendMessageWithContext(ctx context.Context, receiver MessageReceiv
 // 请求头: Content-Type=application/json; charset=utf-8
 //
 
-func (a *app) ReplyMessage(messageID string, msg *Message) (MessageDetail, error) {
-	return a.ReplyMessageWithContext(context.Background(), messageID, msg)
+type replyMessageOption struct {
+	replyInThread bool
+}
+
+type ReplyMessageOption func(*replyMessageOption)
+
+// WithReplyMessageInThread 是否以话题形式回复
+//  若要回复的消息已经是话题形式的消息，则默认以话题形式进行回复
+//  回复后 MessageDetail.ThreadID 为话题 ID, 可用于 GetThreadMessages
+func WithReplyMessageInThread(b bool) ReplyMessageOption {
+	return func(opt *replyMessageOption) {
+		opt.replyInThread = b
+	}
+}
+
+func (a *app) ReplyMessage(messageID string, msg *Message, opts ...ReplyMessageOption) (MessageDetail, error) {
+	return a.ReplyMessageWithContext(context.Background(), messageID, msg, opts...)
 }
 
-func (a *app) ReplyMessageWithContext(ctx context.Context, messageID string, msg *Message) (MessageDetail, error) {
+func (a *app) ReplyMessageWithContext(ctx context.Context, messageID string, msg *Message, opts ...ReplyMessageOption) (MessageDetail, error) {
 	apiDomain := "消息与群组"
 	apiName := "回复消息"
 	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/reply", messageID)
@@ -311,14 +610,26 @@ func (a *app) ReplyMessageWithContext(ctx context.Context, messageID string, msg
 		return MessageDetail{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
 	}
 
+	replyOpt := new(replyMessageOption)
+	for _, fn := range opts {
+		if fn == nil {
+			continue
+		}
+		fn(replyOpt)
+	}
+
 	data := &sendMessageRequest{
-		Content: "",
-		MsgType: msg.msgType,
+		Content:       "",
+		MsgType:       msg.msgType,
+		ReplyInThread: replyOpt.replyInThread,
 	}
-	if bs, err := json.Marshal(msg.content); err != nil {
+	if content, err := msg.marshalContent(); err != nil {
 		return MessageDetail{}, err
 	} else {
-		data.Content = string(bs)
+		data.Content = content
+	}
+	if err := data.checkSize(); err != nil {
+		return MessageDetail{}, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
 	}
 	header := map[string]string{
 		"Content-Type":  "application/json; charset=utf-8",
@@ -347,3 +658,67 @@ func (a *app) ReplyMessageWithContext(ctx context.Context, messageID string, msg
 	return resp.Data, nil
 
 }
+
+// 名称: [消息与群组] 获取指定消息的内容
+// Func: [api_messenger.go] GetMessage
+//
+// 描述: 通过 message_id 查询消息内容
+// Info: 需要开启机器人能力
+// Info: 机器人必须在群组中
+// Info: 获取合并转发消息时，返回合并转发消息本身及其包含的所有子消息，子消息的 upper_message_id 为其上一层级的消息 ID
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message/get
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+type getMessageResponse struct {
+	fsResponse
+	Data struct {
+		Items []MessageDetail `json:"items"`
+	} `json:"data"`
+}
+
+func (a *app) GetMessage(messageID string) ([]MessageDetail, error) {
+	return a.GetMessageWithContext(context.Background(), messageID)
+}
+
+func (a *app) GetMessageWithContext(ctx context.Context, messageID string) ([]MessageDetail, error) {
+	apiDomain := "消息与群组"
+	apiName := "获取指定消息的内容"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s", messageID)
+
+	if !a.isSupported(true, true) {
+		return nil, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return nil, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._getWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(getMessageResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return nil, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return nil, err
+	}
+
+	return resp.Data.Items, nil
+}
@@ -0,0 +1,190 @@
+package feishu
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// _cardActionTimeout 卡片回调需在 3 秒内响应, 预留网络传输的时间
+const _cardActionTimeout = 2500 * time.Millisecond
+
+// CardActionHandler 卡片交互回调的处理函数
+//  ctx 在响应窗口（约 2.5 秒）结束时取消, 超时后返回的响应会被丢弃, 卡片不做任何变化
+//  耗时较长的操作可先返回提示, 再使用 CardAction.Token 延时更新卡片, 参见 DelayUpdateCard
+type CardActionHandler func(ctx context.Context, header EventHeaderV2, action CardAction) CardActionResponse
+
+// RegisterCardActionCallback 注册卡片交互回调（card.action.trigger）的处理函数
+//  回调通过 ListenEventCallback 接收, 需在开发者后台将卡片回调的请求地址配置为事件回调的地址
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-callback-communication
+func (a *app) RegisterCardActionCallback(handler CardActionHandler) {
+	a.cardActionHandler = handler
+}
+
+// CardAction 卡片交互回调的内容
+type CardAction struct {
+	Operator     CardActionOperator `json:"operator"`      // 操作者
+	Token        string             `json:"token"`         // 用于延时更新卡片的凭证, 有效期 30 分钟, 最多可更新 2 次
+	Action       CardActionDetail   `json:"action"`        // 交互信息
+	Host         string             `json:"host"`          // 卡片展示场景, 如 im_message
+	DeliveryType string             `json:"delivery_type"` // 卡片分发类型, 链接预览卡片时为 url_preview
+	Context      CardActionContext  `json:"context"`       // 卡片所在的上下文, 包括消息 ID 及会话 ID
+}
+
+type CardActionOperator struct {
+	TenantKey string `json:"tenant_key"` // 租户 Key
+	OpenID    string `json:"open_id"`    // 操作者的 Open ID
+	UnionID   string `json:"union_id"`   // 操作者的 Union ID
+	UserID    string `json:"user_id"`    // 操作者的 User ID, 需要获取用户 User ID 的权限
+}
+
+type CardActionDetail struct {
+	Tag        string                 `json:"tag"`         // 交互组件的标签, 如 button、select_static
+	Name       string                 `json:"name"`        // 交互组件的 name, 参见 WithCardElementActionName
+	Value      json.RawMessage        `json:"value"`       // 交互组件的 value, 参见 WithCardElementActionValue
+	FormValue  map[string]interface{} `json:"form_value"`  // 表单容器提交的值, 键为交互组件的 name
+	InputValue string                 `json:"input_value"` // 输入框的值
+	Option     string                 `json:"option"`      // 单选下拉选择、人员选择、折叠按钮组、日期选择器选中的值
+	Options    []string               `json:"options"`     // 多选下拉选择选中的值
+	Checked    bool                   `json:"checked"`     // 勾选器是否勾选
+	Timezone   string                 `json:"timezone"`    // 日期选择器的时区
+}
+
+// UnmarshalValue 将交互组件的 value 解析到 v
+func (d CardActionDetail) UnmarshalValue(v interface{}) error {
+	if len(d.Value) == 0 {
+		return errors.New("card action: no value")
+	}
+	return json.Unmarshal(d.Value, v)
+}
+
+type CardActionContext struct {
+	URL           string `json:"url"`             // 链接预览卡片的链接
+	PreviewToken  string `json:"preview_token"`   // 链接预览的 token
+	OpenMessageID string `json:"open_message_id"` // 卡片所在的消息 ID
+	OpenChatID    string `json:"open_chat_id"`    // 卡片所在的会话 ID
+}
+
+// CardToastType 卡片回调弹出提示的类型
+type CardToastType string
+
+const (
+	CardToastInfo    CardToastType = "info"
+	CardToastSuccess CardToastType = "success"
+	CardToastWarning CardToastType = "warning"
+	CardToastError   CardToastType = "error"
+)
+
+// CardToast 卡片回调后弹出的提示
+type CardToast struct {
+	Type    CardToastType       `json:"type"`
+	Content string              `json:"content"`
+	I18n    map[Language]string `json:"i18n,omitempty"` // 多语言提示, 优先于 Content
+}
+
+// CardActionResponse 卡片交互回调的响应
+//  Toast 与 Card 均为空时, 卡片不做任何变化
+type CardActionResponse struct {
+	Toast *CardToast // 弹出提示, 为空时不提示
+	Card  *Message   // 更新后的卡片, 支持 NewMessageCard、NewMessageCardV2、NewMessageCardTemplate, 为空时不更新
+}
+
+type cardActionResponseBody struct {
+	Toast *CardToast              `json:"toast,omitempty"`
+	Card  *cardActionResponseCard `json:"card,omitempty"`
+}
+
+type cardActionResponseCard struct {
+	Type string      `json:"type"` // raw: 卡片 JSON, template: 卡片模板
+	Data interface{} `json:"data"`
+}
+
+func (resp CardActionResponse) marshal() ([]byte, error) {
+	body := cardActionResponseBody{Toast: resp.Toast}
+	if resp.Card != nil {
+		card, err := buildCardActionResponseCard(resp.Card)
+		if err != nil {
+			return nil, err
+		}
+		body.Card = card
+	}
+	return json.Marshal(body)
+}
+
+func buildCardActionResponseCard(msg *Message) (*cardActionResponseCard, error) {
+	if MessageType(msg.msgType) != MsgTypeInteractive {
+		return nil, fmt.Errorf("unsupported message type: %s", msg.msgType)
+	}
+	if msg.err != nil {
+		return nil, msg.err
+	}
+	if tpl, ok := msg.content.(cardTemplate); ok {
+		return &cardActionResponseCard{Type: tpl.Type, Data: tpl.Data}, nil
+	}
+	return &cardActionResponseCard{Type: "raw", Data: msg.content}, nil
+}
+
+// verifySignature 校验回调请求的签名
+//  配置了 Encrypt Key 时, 请求头 X-Lark-Signature 为 sha256(timestamp + nonce + encryptKey + body)
+func verifySignature(header http.Header, encryptKey string, body []byte) error {
+	if encryptKey == "" {
+		return nil
+	}
+	signature := header.Get("X-Lark-Signature")
+	if signature == "" {
+		return errors.New("missing signature")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header.Get("X-Lark-Request-Timestamp"))
+	buf.WriteString(header.Get("X-Lark-Request-Nonce"))
+	buf.WriteString(encryptKey)
+	buf.Write(body)
+	sum := sha256.Sum256(buf.Bytes())
+
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(signature)) != 1 {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// serveCardAction 处理卡片交互回调, 在响应窗口内返回处理函数的响应
+//  处理函数超时时返回空的响应并记录日志, 避免用户看到回调失败的提示
+func (a *app) serveCardAction(ctx context.Context, w http.ResponseWriter, header EventHeaderV2, event json.RawMessage, opt *_doOpt) error {
+	var action CardAction
+	if err := json.Unmarshal(event, &action); err != nil {
+		return fmt.Errorf("unmarshal card action: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, _cardActionTimeout)
+	defer cancel()
+
+	handler := a.cardActionHandler
+	done := make(chan CardActionResponse, 1)
+	go func() {
+		done <- handler(ctx, header, action)
+	}()
+
+	var resp CardActionResponse
+	select {
+	case resp = <-done:
+	case <-ctx.Done():
+		opt.debugLog(fmt.Sprintf("[%s - %s] card action handler: %s\n", opt.apiDomain, opt.apiName, ctx.Err()))
+		resp = CardActionResponse{}
+	}
+
+	bs, err := resp.marshal()
+	if err != nil {
+		return fmt.Errorf("marshal card action response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, err = w.Write(bs)
+	return err
+}
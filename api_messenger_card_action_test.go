@@ -0,0 +1,168 @@
+package feishu
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testCardActionEvent = `{
+	"schema": "2.0",
+	"header": {"event_id": "e1", "event_type": "card.action.trigger", "token": "v_token", "app_id": "cli_x"},
+	"event": {
+		"operator": {"tenant_key": "t1", "open_id": "ou_1", "union_id": "on_1"},
+		"token": "c-123",
+		"action": {"tag": "button", "name": "rollback", "value": {"op": "rollback", "id": 7}, "form_value": {"reason": "bad"}},
+		"host": "im_message",
+		"context": {"open_message_id": "om_1", "open_chat_id": "oc_1"}
+	}
+}`
+
+func testEncryptEvent(t *testing.T, key, plain string) []byte {
+	t.Helper()
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	requireNil(t, err)
+
+	padding := aes.BlockSize - len(plain)%aes.BlockSize
+	src := append([]byte(plain), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	dst := make([]byte, aes.BlockSize+len(src))
+	iv := dst[:aes.BlockSize]
+	copy(iv, "0123456789abcdef")
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(dst[aes.BlockSize:], src)
+
+	bs, err := json.Marshal(map[string]string{"encrypt": base64.StdEncoding.EncodeToString(dst)})
+	requireNil(t, err)
+	return bs
+}
+
+func testCardActionRequest(t *testing.T, key string, body []byte, signed bool) *http.Request {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	r.Header.Set("X-Lark-Request-Timestamp", "1700000000")
+	r.Header.Set("X-Lark-Request-Nonce", "nonce")
+	if signed {
+		sum := sha256.Sum256([]byte("1700000000" + "nonce" + key + string(body)))
+		r.Header.Set("X-Lark-Signature", hex.EncodeToString(sum[:]))
+	}
+	return r
+}
+
+func Test_app_ListenEventCallback_cardAction(t *testing.T) {
+	const key = "encrypt_key"
+	fsApp := newApp("cli_x", "secret", WithAppEventEncryptKey(key), WithAppEventVerificationToken("v_token"))
+
+	var got CardAction
+	fsApp.RegisterCardActionCallback(func(ctx context.Context, header EventHeaderV2, action CardAction) CardActionResponse {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("expected a deadline on the handler context")
+		}
+		got = action
+		return CardActionResponse{
+			Toast: &CardToast{Type: CardToastSuccess, Content: "done"},
+			Card: NewMessageCard(BgColorGreen, nil,
+				WithCard(LangChinese, "已回滚", WithCardElementPlainText("ok")),
+			),
+		}
+	})
+
+	body := testEncryptEvent(t, key, testCardActionEvent)
+	w := httptest.NewRecorder()
+	fsApp.ListenEventCallback(w, testCardActionRequest(t, key, body, true))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if got.Token != "c-123" || got.Operator.OpenID != "ou_1" || got.Context.OpenMessageID != "om_1" {
+		t.Fatalf("unexpected action: %+v", got)
+	}
+	if got.Action.Name != "rollback" || got.Action.FormValue["reason"] != "bad" {
+		t.Fatalf("unexpected action detail: %+v", got.Action)
+	}
+	var value struct {
+		Op string `json:"op"`
+		ID int    `json:"id"`
+	}
+	requireNil(t, got.Action.UnmarshalValue(&value))
+	if value.Op != "rollback" || value.ID != 7 {
+		t.Fatalf("unexpected value: %+v", value)
+	}
+
+	var resp struct {
+		Toast CardToast `json:"toast"`
+		Card  struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		} `json:"card"`
+	}
+	requireNil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if resp.Toast.Type != CardToastSuccess || resp.Toast.Content != "done" {
+		t.Fatalf("unexpected toast: %s", w.Body.String())
+	}
+	if resp.Card.Type != "raw" || !strings.Contains(string(resp.Card.Data), `"已回滚"`) {
+		t.Fatalf("unexpected card: %s", w.Body.String())
+	}
+}
+
+func Test_app_ListenEventCallback_cardActionSignature(t *testing.T) {
+	const key = "encrypt_key"
+	fsApp := newApp("cli_x", "secret", WithAppEventEncryptKey(key), WithAppEventVerificationToken("v_token"))
+
+	called := false
+	fsApp.RegisterCardActionCallback(func(context.Context, EventHeaderV2, CardAction) CardActionResponse {
+		called = true
+		return CardActionResponse{}
+	})
+
+	body := testEncryptEvent(t, key, testCardActionEvent)
+	for _, r := range []*http.Request{
+		testCardActionRequest(t, key, body, false),
+		testCardActionRequest(t, "other_key", body, true),
+	} {
+		w := httptest.NewRecorder()
+		fsApp.ListenEventCallback(w, r)
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("status = %d, want 500", w.Code)
+		}
+	}
+	if called {
+		t.Fatal("handler called for a request with an invalid signature")
+	}
+}
+
+func Test_app_ListenEventCallback_cardActionTimeout(t *testing.T) {
+	fsApp := newApp("cli_x", "secret", WithAppEventVerificationToken("v_token"))
+	fsApp.RegisterCardActionCallback(func(ctx context.Context, _ EventHeaderV2, _ CardAction) CardActionResponse {
+		<-ctx.Done()
+		return CardActionResponse{Toast: &CardToast{Type: CardToastInfo, Content: "too late"}}
+	})
+
+	w := httptest.NewRecorder()
+	fsApp.ListenEventCallback(w, testCardActionRequest(t, "", []byte(testCardActionEvent), false))
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "{}" {
+		t.Fatalf("got %d %q, want 200 {}", w.Code, w.Body.String())
+	}
+}
+
+func TestCardActionResponse_marshal(t *testing.T) {
+	bs, err := CardActionResponse{
+		Card: NewMessageCardTemplate("tpl_1", "1.0.0", map[string]string{"status": "running"}),
+	}.marshal()
+	requireNil(t, err)
+	if want := `{"card":{"type":"template","data":{"template_id":"tpl_1","template_version_name":"1.0.0","template_variable":{"status":"running"}}}}`; string(bs) != want {
+		t.Fatalf("got %s\nwant %s", bs, want)
+	}
+
+	if _, err = (CardActionResponse{Card: NewMessageText("hi")}).marshal(); err == nil {
+		t.Fatal("expected an error for a non-card message")
+	}
+}
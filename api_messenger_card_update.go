@@ -0,0 +1,117 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 名称: [消息卡片] 延时更新消息卡片
+// Func: [api_messenger_card_update.go] DelayUpdateCard
+//
+// 描述: 用户与卡片交互后, 使用回调中的 token 延时更新卡片
+// Info: 需要开启机器人能力
+// Info: token 来自卡片交互回调（CardAction.Token）, 有效期 30 分钟, 最多可更新 2 次
+// Info: 需在卡片交互回调响应后再调用, 即不能在 CardActionHandler 返回前调用
+// Info: openIDs 为空时更新所有人可见的卡片, 仅对独享卡片（WithCardConfigEnableUpdateMulti(false)）可指定更新的用户, 共享卡片指定时返回错误
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-card/delay-update-message-card
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/interactive/v1/card/update
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{TenantAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type delayUpdateCardRequest struct {
+	Token string          `json:"token"` // 卡片交互回调中的 token
+	Card  json.RawMessage `json:"card"`  // 消息卡片的内容, 独享卡片可在其中指定 open_ids
+}
+
+func (a *app) DelayUpdateCard(token string, card *Message, openIDs ...string) error {
+	return a.DelayUpdateCardWithContext(context.Background(), token, card, openIDs...)
+}
+
+func (a *app) DelayUpdateCardWithContext(ctx context.Context, token string, card *Message, openIDs ...string) error {
+	apiDomain := "消息卡片"
+	apiName := "延时更新消息卡片"
+	urlSuffix := "/open-apis/interactive/v1/card/update"
+
+	if !a.isSupported(true, true) {
+		return fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	if MessageType(card.msgType) != MsgTypeInteractive {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, fmt.Errorf("unsupported message type: %s", card.msgType))
+	}
+	content, err := buildDelayUpdateCard(card, openIDs)
+	if err != nil {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+	data := &delayUpdateCardRequest{
+		Token: token,
+		Card:  content,
+	}
+
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getTenantAccessTokenWithContext(ctx); err != nil {
+		return err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return err
+	}
+
+	resp := new(fsResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return err
+	}
+
+	return resp.check(reqID, apiDomain, apiName)
+}
+
+// buildDelayUpdateCard 序列化卡片内容, openIDs 不为空时写入卡片的 open_ids
+//  共享卡片不支持指定 open_ids, 在发送前返回错误
+func buildDelayUpdateCard(card *Message, openIDs []string) (json.RawMessage, error) {
+	content, err := card.marshalContent()
+	if err != nil {
+		return nil, err
+	}
+	if len(openIDs) == 0 {
+		return json.RawMessage(content), nil
+	}
+	if isSharedCard(card) {
+		return nil, errors.New("open_ids only applies to exclusive cards (update_multi=false)")
+	}
+
+	m := make(map[string]json.RawMessage)
+	if err = json.Unmarshal([]byte(content), &m); err != nil {
+		return nil, err
+	}
+	if m["open_ids"], err = json.Marshal(openIDs); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// isSharedCard 卡片是否为共享卡片(update_multi=true)
+//  JSON 2.0 结构的卡片仅支持共享卡片, 未指定时视为共享卡片
+func isSharedCard(card *Message) bool {
+	switch c := card.content.(type) {
+	case *msgCard:
+		return c.Config != nil && c.Config.UpdateMulti != nil && *c.Config.UpdateMulti
+	case *msgCardV2:
+		return c.Config == nil || c.Config.UpdateMulti == nil || *c.Config.UpdateMulti
+	}
+	return false
+}
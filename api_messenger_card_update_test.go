@@ -0,0 +1,80 @@
+package feishu
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_app_DelayUpdateCard(t *testing.T) {
+	var got []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/auth/v3/tenant_access_token/internal":
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"ok","tenant_access_token":"t-test","expire":7200}`)
+		case "/open-apis/interactive/v1/card/update":
+			if auth := r.Header.Get("Authorization"); auth != "Bearer t-test" {
+				t.Errorf("Authorization = %q", auth)
+			}
+			req := make(map[string]interface{})
+			requireNil(t, json.NewDecoder(r.Body).Decode(&req))
+			got = append(got, req)
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"success"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fsApp := newApp("cli_test", "secret", WithAppOpenBaseURL(srv.URL))
+	fsApp.isCustomApp = true
+
+	result := func(title string) *Message {
+		return NewMessageCard(BgColorGreen, WithCardConfig(WithCardConfigEnableUpdateMulti(false)),
+			WithCard(LangChinese, title, WithCardElementPlainText("v1.2.3")),
+		)
+	}
+	requireNil(t, fsApp.DelayUpdateCard("c-123", result("回滚完成")))
+	requireNil(t, fsApp.DelayUpdateCard("c-123", result("回滚完成"), "ou_1", "ou_2"))
+
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	for i, req := range got {
+		if req["token"] != "c-123" {
+			t.Fatalf("request %d: token = %v", i, req["token"])
+		}
+		card, _ := req["card"].(map[string]interface{})
+		if _, ok := card["i18n_elements"]; !ok {
+			t.Fatalf("request %d: unexpected card %v", i, card)
+		}
+		openIDs, ok := card["open_ids"]
+		if ok != (i == 1) {
+			t.Fatalf("request %d: open_ids = %v", i, openIDs)
+		}
+	}
+	if ids := fmt.Sprint(got[1]["card"].(map[string]interface{})["open_ids"]); ids != "[ou_1 ou_2]" {
+		t.Fatalf("open_ids = %s", ids)
+	}
+
+	if err := fsApp.DelayUpdateCard("c-123", NewMessageText("hi")); err == nil {
+		t.Fatal("expected an error for a non-card message")
+	}
+
+	shared := []*Message{
+		NewMessageCard(BgColorGreen, WithCardConfig(WithCardConfigEnableUpdateMulti(true)),
+			WithCard(LangChinese, "回滚完成", WithCardElementPlainText("v1.2.3"))),
+		NewMessageCardV2(WithCardHeader("回滚完成"), nil, WithCardElementPlainText("v1.2.3")),
+	}
+	for i, card := range shared {
+		if err := fsApp.DelayUpdateCard("c-123", card, "ou_1"); err == nil {
+			t.Fatalf("shared card %d: expected an error for open_ids", i)
+		}
+	}
+	requireNil(t, fsApp.DelayUpdateCard("c-123", shared[0]))
+	if len(got) != 3 {
+		t.Fatalf("got %d requests, want 3: shared cards with open_ids must not be sent", len(got))
+	}
+}
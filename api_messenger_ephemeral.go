@@ -0,0 +1,167 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// 名称: [消息卡片] 发送仅特定人可见的消息卡片
+// Func: [api_messenger_ephemeral.go] SendEphemeralCard
+//
+// 描述: 用于机器人在群会话中发送仅指定用户可见的消息卡片
+// Info: 需要开启机器人能力
+// Info: 需要机器人在会话群里
+// Info: 仅在群聊场景下可以发送；仅支持消息卡片（NewMessageCard 构造的卡片）
+// Info: 临时消息卡片的 user 仅支持 OpenID, UserID, Email
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-card/send-message-cards-that-are-only-visible-to-certain-people
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/ephemeral/v1/send
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{TenantAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type sendEphemeralCardRequest struct {
+	ChatID  string          `json:"chat_id"`           // 发送临时消息的群 ID
+	OpenID  string          `json:"open_id,omitempty"` // 指定发送给用户的 open_id, 与 user_id, email 三选一
+	UserID  string          `json:"user_id,omitempty"` // 指定发送给用户的 user_id
+	Email   string          `json:"email,omitempty"`   // 指定发送给用户的 email
+	MsgType string          `json:"msg_type"`          // 消息类型, 固定为 interactive
+	Card    json.RawMessage `json:"card"`              // 消息卡片的内容
+}
+
+type sendEphemeralCardResponse struct {
+	fsResponse
+	Data struct {
+		MessageID string `json:"message_id"`
+	} `json:"data"`
+}
+
+func (a *app) SendEphemeralCard(chatID string, user MessageReceiver, card *Message) (messageID string, err error) {
+	return a.SendEphemeralCardWithContext(context.Background(), chatID, user, card)
+}
+
+func (a *app) SendEphemeralCardWithContext(ctx context.Context, chatID string, user MessageReceiver, card *Message) (messageID string, err error) {
+	apiDomain := "消息卡片"
+	apiName := "发送仅特定人可见的消息卡片"
+	urlSuffix := "/open-apis/ephemeral/v1/send"
+
+	if !a.isSupported(true, true) {
+		return "", fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &sendEphemeralCardRequest{
+		ChatID:  chatID,
+		MsgType: card.msgType,
+	}
+	switch user.IDType {
+	case OpenID:
+		data.OpenID = user.ID
+	case UserID:
+		data.UserID = user.ID
+	case Email:
+		data.Email = user.ID
+	default:
+		return "", fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, fmt.Errorf("unsupported user id type: %s", user.IDType))
+	}
+	if MessageType(card.msgType) != MsgTypeInteractive {
+		return "", fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, fmt.Errorf("unsupported message type: %s", card.msgType))
+	}
+	if content, err := card.marshalContent(); err != nil {
+		return "", err
+	} else {
+		data.Card = json.RawMessage(content)
+	}
+
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getTenantAccessTokenWithContext(ctx); err != nil {
+		return "", err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	resp := new(sendEphemeralCardResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return "", err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return "", err
+	}
+
+	return resp.Data.MessageID, nil
+}
+
+// 名称: [消息卡片] 删除仅特定人可见的消息卡片
+// Func: [api_messenger_ephemeral.go] DeleteEphemeralCard
+//
+// 描述: 在群会话中删除指定用户可见的临时消息卡片
+// Info: 需要开启机器人能力
+// Info: 临时消息卡片只能由发送者（机器人）删除
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-card/delete-message-cards-that-are-only-visible-to-certain-people
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/ephemeral/v1/delete
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{TenantAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type deleteEphemeralCardRequest struct {
+	MessageID string `json:"message_id"` // 临时消息 ID
+}
+
+func (a *app) DeleteEphemeralCard(messageID string) error {
+	return a.DeleteEphemeralCardWithContext(context.Background(), messageID)
+}
+
+func (a *app) DeleteEphemeralCardWithContext(ctx context.Context, messageID string) error {
+	apiDomain := "消息卡片"
+	apiName := "删除仅特定人可见的消息卡片"
+	urlSuffix := "/open-apis/ephemeral/v1/delete"
+
+	if !a.isSupported(true, true) {
+		return fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &deleteEphemeralCardRequest{
+		MessageID: messageID,
+	}
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getTenantAccessTokenWithContext(ctx); err != nil {
+		return err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return err
+	}
+
+	resp := new(fsResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return err
+	}
+
+	return resp.check(reqID, apiDomain, apiName)
+}
@@ -0,0 +1,28 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_SendEphemeralCard(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	card := NewMessageCard(BgColorRed, nil,
+		WithCard(LangChinese, "参数错误",
+			WithCardElementMarkdown("用法: `/deploy <service> <version>`"),
+		),
+	)
+	user := MessageReceiver{
+		IDType: OpenID,
+		ID:     "ou_c99c5f35d542efc7ee492afe11af19ef",
+	}
+
+	messageID, err := fsApp.SendEphemeralCard("oc_5f2c7c2066c6be483bb0302f2fa0c04f", user, card)
+	requireNil(t, err)
+
+	t.Log(messageID)
+
+	err = fsApp.DeleteEphemeralCard(messageID)
+	requireNil(t, err)
+}
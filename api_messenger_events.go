@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
+	"strings"
 )
 
 type EventHandler func(header EventHeaderV2, event json.RawMessage)
@@ -37,6 +39,7 @@ func (a *app) ListenEventCallback(w http.ResponseWriter, r *http.Request) {
 		_ = r.Body.Close()
 	}()
 
+	raw := body.Bytes()
 	eReq := new(eventRequest)
 	if err = json.Unmarshal(body.Bytes(), eReq); err != nil {
 		opt.debugLog(fmt.Sprintf("[%s - %s] unmarshal event: %s\n", opt.apiDomain, opt.apiName, err))
@@ -85,6 +88,16 @@ func (a *app) ListenEventCallback(w http.ResponseWriter, r *http.Request) {
 			opt.debugLog(fmt.Sprintf("[%s - %s] unexpected event callback token (app: %s): %s\n", opt.apiDomain, opt.apiName, a.verificationToken, eReq.Header.Token))
 			return
 		}
+		if eReq.Header.EventType == EventTypeCardActionTrigger && a.cardActionHandler != nil {
+			if err = verifySignature(r.Header, a.encryptKey, raw); err != nil {
+				opt.debugLog(fmt.Sprintf("[%s - %s] verify card action: %s\n", opt.apiDomain, opt.apiName, err))
+				return
+			}
+			if err = a.serveCardAction(r.Context(), w, *eReq.Header, eReq.Event, opt); err != nil {
+				opt.debugLog(fmt.Sprintf("[%s - %s] %s\n", opt.apiDomain, opt.apiName, err))
+			}
+			return
+		}
 		handler, ok := a.eventHandler[eReq.Header.EventType]
 		if ok {
 			go handler(*eReq.Header, eReq.Event)
@@ -121,6 +134,11 @@ type EventType string
 const (
 	EventTypeURLVerification EventType = "url_verification"
 	EventTypeMessageReceived EventType = "im.message.receive_v1" // 接收消息 v2.0
+
+	EventTypeMessageReactionCreated EventType = "im.message.reaction.created_v1" // 新增消息表情回复 v2.0
+	EventTypeMessageReactionDeleted EventType = "im.message.reaction.deleted_v1" // 删除消息表情回复 v2.0
+
+	EventTypeCardActionTrigger EventType = "card.action.trigger" // 卡片交互回调 v2.0, 参见 RegisterCardActionCallback
 )
 
 type eventRequest struct {
@@ -250,4 +268,75 @@ type EventMessage struct {
 	MessageType string         `json:"message_type"` // 消息类型
 	Content     string         `json:"content"`      // 消息内容, json 格式各类型消息Content
 	Mentions    []EventMention `json:"mentions"`     // 被提及用户的信息
+	ThreadID    string         `json:"thread_id"`    // 消息所属的话题 ID
+}
+
+// EventMessageReaction 新增/删除消息表情回复
+//  EventTypeMessageReactionCreated, EventTypeMessageReactionDeleted
+type EventMessageReaction struct {
+	MessageID    string       `json:"message_id"`    // 消息的 open_message_id
+	ReactionType ReactionType `json:"reaction_type"` // 表情回复的资源类型
+	OperatorType string       `json:"operator_type"` // 操作人类型: app, user
+	UserID       EventUserID  `json:"user_id"`       // 用户 ID, OperatorType 为 user 时有值
+	AppID        string       `json:"app_id"`        // 应用 ID, OperatorType 为 app 时有值
+	ActionTime   string       `json:"action_time"`   // 添加/删除表情回复的时间戳（毫秒）
+}
+
+var _mentionPlaceholder = regexp.MustCompile(`@_[a-z]+(?:_[0-9]+)?`)
+
+// ReplaceMentions 将文本中 @ 的占位符（如 @_user_1）替换为 repl 的返回值
+//  mentions 通常为 EventMessage.Mentions, 未出现在 mentions 中的占位符保持不变
+//  可使用 MentionName, MentionOpenID, 或自定义替换规则
+func ReplaceMentions(text string, mentions []EventMention, repl func(m EventMention) string) string {
+	if len(mentions) == 0 {
+		return text
+	}
+
+	replaced := make(map[string]string, len(mentions))
+	for _, m := range mentions {
+		if m.Key == "" {
+			continue
+		}
+		replaced[m.Key] = repl(m)
+	}
+	return _mentionPlaceholder.ReplaceAllStringFunc(text, func(key string) string {
+		if v, ok := replaced[key]; ok {
+			return v
+		}
+		return key
+	})
+}
+
+// MentionName 将占位符替换为 @用户名
+func MentionName(m EventMention) string {
+	return "@" + m.Name
+}
+
+// MentionOpenID 将占位符替换为被 @ 用户的 open_id
+func MentionOpenID(m EventMention) string {
+	return m.ID.OpenID
+}
+
+// StripBotMention 删除文本中 @机器人 的占位符, 其余占位符替换为 @用户名, 并去除首尾空白
+//  botOpenID 可通过 GetBotInfo 获取
+//  mentioned 表示机器人是否被 @
+func StripBotMention(text string, mentions []EventMention, botOpenID string) (cleaned string, mentioned bool) {
+	cleaned = ReplaceMentions(text, mentions, func(m EventMention) string {
+		if botOpenID != "" && m.ID.OpenID == botOpenID {
+			mentioned = true
+			return ""
+		}
+		return MentionName(m)
+	})
+	return strings.TrimSpace(cleaned), mentioned
+}
+
+// IsMentioned 消息中是否 @ 了指定用户或机器人
+func (m EventMessage) IsMentioned(openID string) bool {
+	for _, mention := range m.Mentions {
+		if mention.ID.OpenID == openID {
+			return true
+		}
+	}
+	return false
 }
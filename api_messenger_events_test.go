@@ -156,6 +156,14 @@ func Test_app_ListenEventCallback(t *testing.T) {
 		logIndent(t, msg)
 	})
 
+	fsApp.RegisterEventCallback(EventTypeMessageReactionCreated, func(header EventHeaderV2, event json.RawMessage) {
+		reaction := new(EventMessageReaction)
+		err := json.Unmarshal(event, reaction)
+		requireNil(t, err)
+
+		logIndent(t, reaction)
+	})
+
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fsApp.ListenEventCallback(w, r)
 	})
@@ -163,3 +171,36 @@ func Test_app_ListenEventCallback(t *testing.T) {
 	err := http.ListenAndServe(":60081", nil)
 	requireNil(t, err)
 }
+
+func TestReplaceMentions(t *testing.T) {
+	mentions := make([]EventMention, 0, 10)
+	for i := 1; i <= 10; i++ {
+		mentions = append(mentions, EventMention{
+			Key:  fmt.Sprintf("@_user_%d", i),
+			ID:   EventUserID{OpenID: fmt.Sprintf("ou_%d", i)},
+			Name: fmt.Sprintf("user%d", i),
+		})
+	}
+
+	text := "@_user_1 @_user_10 hi @_user_2 @_user_11"
+	if got, want := ReplaceMentions(text, mentions, MentionName), "@user1 @user10 hi @user2 @_user_11"; got != want {
+		t.Fatalf("ReplaceMentions(MentionName) = %q, want %q", got, want)
+	}
+	if got, want := ReplaceMentions(text, mentions, MentionOpenID), "ou_1 ou_10 hi ou_2 @_user_11"; got != want {
+		t.Fatalf("ReplaceMentions(MentionOpenID) = %q, want %q", got, want)
+	}
+
+	cleaned, mentioned := StripBotMention("@_user_1 /deploy @_user_2", mentions[:2], "ou_1")
+	if cleaned != "/deploy @user2" || !mentioned {
+		t.Fatalf("StripBotMention() = %q, %v", cleaned, mentioned)
+	}
+	cleaned, mentioned = StripBotMention("@_user_2 hello", mentions[:2], "ou_bot")
+	if cleaned != "@user2 hello" || mentioned {
+		t.Fatalf("StripBotMention() = %q, %v", cleaned, mentioned)
+	}
+
+	msg := EventMessage{Mentions: mentions[:2]}
+	if !msg.IsMentioned("ou_2") || msg.IsMentioned("ou_3") {
+		t.Fatal("unexpected IsMentioned()")
+	}
+}
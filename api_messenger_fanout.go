@@ -0,0 +1,223 @@
+package feishu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SendMessageResult 单个接收者的发送结果
+//  Err 为 *APIError 时可通过 errors.As 获取错误码
+type SendMessageResult struct {
+	Detail MessageDetail
+	Err    error
+}
+
+// 发送消息接口的频率限制为 1000 次/分钟、50 次/秒
+const (
+	_msgSendMaxQPS = 50
+	_msgSendMaxQPM = 1000
+)
+
+type sendMessageToManyOption struct {
+	concurrency int
+	qps         int
+	qpm         int
+	window      time.Duration // 每分钟限制的统计窗口, 测试时可缩短
+	retry       int
+}
+
+type SendMessageToManyOption func(*sendMessageToManyOption)
+
+// WithSendMessageToManyConcurrency 同时发送的最大请求数, 默认 10
+func WithSendMessageToManyConcurrency(n int) SendMessageToManyOption {
+	return func(opt *sendMessageToManyOption) {
+		if n > 0 {
+			opt.concurrency = n
+		}
+	}
+}
+
+// WithSendMessageToManyQPS 每秒最多发送的请求数, 默认 50, 超过 50 时按 50 处理
+//  发送消息接口的频率限制为 1000 次/分钟、50 次/秒, 每分钟的请求数始终不超过 1000
+func WithSendMessageToManyQPS(qps int) SendMessageToManyOption {
+	return func(opt *sendMessageToManyOption) {
+		if qps > 0 {
+			opt.qps = qps
+		}
+		if opt.qps > _msgSendMaxQPS {
+			opt.qps = _msgSendMaxQPS
+		}
+	}
+}
+
+// WithSendMessageToManyRetry 触发频率限制时的最大重试次数, 默认 3
+//  重试前等待至下一个分钟窗口
+func WithSendMessageToManyRetry(n int) SendMessageToManyOption {
+	return func(opt *sendMessageToManyOption) {
+		if n >= 0 {
+			opt.retry = n
+		}
+	}
+}
+
+// SendMessageToMany 将同一条消息逐个发送给多个接收者
+//  消息内容只序列化一次, 按 WithSendMessageToManyConcurrency, WithSendMessageToManyQPS 限制并发及频率,
+//  同时保证每分钟不超过 1000 次请求
+//  返回每个接收者的发送结果, 重复的接收者只发送一次
+//  error 仅表示发送前的错误（如消息内容无法序列化、超出大小限制）
+func (a *app) SendMessageToMany(receivers []MessageReceiver, msg *Message, opts ...SendMessageToManyOption) (map[MessageReceiver]SendMessageResult, error) {
+	return a.SendMessageToManyWithContext(context.Background(), receivers, msg, opts...)
+}
+
+func (a *app) SendMessageToManyWithContext(ctx context.Context, receivers []MessageReceiver, msg *Message, opts ...SendMessageToManyOption) (map[MessageReceiver]SendMessageResult, error) {
+	apiDomain := "消息与群组"
+	apiName := "发送消息"
+
+	if !a.isSupported(true, true) {
+		return nil, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	opt := &sendMessageToManyOption{
+		concurrency: 10,
+		qps:         _msgSendMaxQPS,
+		qpm:         _msgSendMaxQPM,
+		window:      time.Minute,
+		retry:       3,
+	}
+	for _, fn := range opts {
+		if fn == nil {
+			continue
+		}
+		fn(opt)
+	}
+
+	content, err := msg.marshalContent()
+	if err != nil {
+		return nil, err
+	}
+
+	uniq := make([]MessageReceiver, 0, len(receivers))
+	seen := make(map[MessageReceiver]struct{}, len(receivers))
+	longest := MessageReceiver{}
+	for _, r := range receivers {
+		if _, ok := seen[r]; ok {
+			continue
+		}
+		seen[r] = struct{}{}
+		uniq = append(uniq, r)
+		if len(r.ID) > len(longest.ID) {
+			longest = r
+		}
+	}
+
+	// 以最长的接收者 ID 校验请求体大小
+	if err = (&sendMessageRequest{ReceiveID: longest.ID, Content: content, MsgType: msg.msgType}).checkSize(); err != nil {
+		return nil, fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	limiter := newSendRateLimiter(opt.qps, opt.qpm, opt.window)
+
+	var (
+		results = make(map[MessageReceiver]SendMessageResult, len(uniq))
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, opt.concurrency)
+	)
+	for _, r := range uniq {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r MessageReceiver) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			var (
+				detail MessageDetail
+				err    error
+			)
+			for attempt := 0; ; attempt++ {
+				if err = limiter.wait(ctx); err != nil {
+					break
+				}
+				data := &sendMessageRequest{
+					ReceiveID: r.ID,
+					Content:   content,
+					MsgType:   msg.msgType,
+				}
+				detail, err = a.sendMessageWithContext(ctx, r.IDType, data)
+
+				var apiErr *APIError
+				if err == nil || attempt >= opt.retry || !errors.As(err, &apiErr) || !apiErr.IsRateLimited() {
+					break
+				}
+				limiter.exhaust()
+			}
+
+			mu.Lock()
+			results[r] = SendMessageResult{Detail: detail, Err: err}
+			mu.Unlock()
+		}(r)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// sendRateLimiter 同时限制每秒及每个窗口(默认一分钟)内的请求数
+//  每次 wait 预约一个发送时间: 与上一次间隔至少 interval, 窗口内的请求数用尽时顺延至下一个窗口
+type sendRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	budget   int
+	window   time.Duration
+
+	next        time.Time // 下一次请求的最早时间
+	windowStart time.Time
+	count       int // 当前窗口内已预约的请求数
+}
+
+func newSendRateLimiter(qps, budget int, window time.Duration) *sendRateLimiter {
+	return &sendRateLimiter{
+		interval: time.Second / time.Duration(qps),
+		budget:   budget,
+		window:   window,
+	}
+}
+
+func (l *sendRateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	at := time.Now()
+	if at.Before(l.next) {
+		at = l.next
+	}
+	if l.windowStart.IsZero() || !at.Before(l.windowStart.Add(l.window)) {
+		l.windowStart, l.count = at, 0
+	}
+	if l.count >= l.budget {
+		at = l.windowStart.Add(l.window)
+		l.windowStart, l.count = at, 0
+	}
+	l.count++
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// exhaust 触发频率限制时用尽当前窗口, 之后的请求(包括重试)均等待至下一个窗口
+func (l *sendRateLimiter) exhaust() {
+	l.mu.Lock()
+	l.count = l.budget
+	l.mu.Unlock()
+}
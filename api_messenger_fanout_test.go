@@ -0,0 +1,131 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_app_SendMessageToMany(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/auth/v3/app_access_token/internal":
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"ok","app_access_token":"t-test","expire":7200}`)
+		case "/open-apis/im/v1/messages":
+			req := new(sendMessageRequest)
+			requireNil(t, json.NewDecoder(r.Body).Decode(req))
+
+			mu.Lock()
+			attempts[req.ReceiveID]++
+			n := attempts[req.ReceiveID]
+			mu.Unlock()
+
+			switch {
+			case req.ReceiveID == "ou_limited" && n == 1:
+				_, _ = fmt.Fprint(w, `{"code":99991400,"msg":"request trigger frequency limit"}`)
+			case req.ReceiveID == "ou_invalid":
+				_, _ = fmt.Fprint(w, `{"code":230013,"msg":"Bot has NO availability to this user."}`)
+			default:
+				_, _ = fmt.Fprintf(w, `{"code":0,"msg":"success","data":{"message_id":"om_%s","msg_type":%q}}`, req.ReceiveID, req.MsgType)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fsApp := newApp("cli_test", "secret", WithAppOpenBaseURL(srv.URL))
+	fsApp.isCustomApp = true
+
+	receivers := make([]MessageReceiver, 0, 32)
+	for i := 0; i < 30; i++ {
+		receivers = append(receivers, MessageReceiver{IDType: OpenID, ID: fmt.Sprintf("ou_%d", i)})
+	}
+	receivers = append(receivers,
+		MessageReceiver{IDType: OpenID, ID: "ou_limited"},
+		MessageReceiver{IDType: OpenID, ID: "ou_invalid"},
+		MessageReceiver{IDType: OpenID, ID: "ou_0"},
+	)
+
+	results, err := fsApp.SendMessageToMany(receivers, NewMessageText("hello"),
+		WithSendMessageToManyConcurrency(4),
+		WithSendMessageToManyQPS(1000),
+		func(opt *sendMessageToManyOption) { opt.window = 100 * time.Millisecond },
+	)
+	requireNil(t, err)
+
+	if len(results) != 32 {
+		t.Fatalf("len(results) = %d, want 32", len(results))
+	}
+	for r, result := range results {
+		if r.ID == "ou_invalid" {
+			var apiErr *APIError
+			if !errors.As(result.Err, &apiErr) || apiErr.Code != 230013 {
+				t.Fatalf("%s: unexpected error: %v", r.ID, result.Err)
+			}
+			continue
+		}
+		requireNil(t, result.Err)
+		if result.Detail.MessageID != "om_"+r.ID {
+			t.Fatalf("%s: unexpected detail: %+v", r.ID, result.Detail)
+		}
+	}
+	if attempts["ou_0"] != 1 || attempts["ou_limited"] != 2 {
+		t.Fatalf("unexpected attempts: ou_0=%d, ou_limited=%d", attempts["ou_0"], attempts["ou_limited"])
+	}
+}
+
+func TestWithSendMessageToManyQPS(t *testing.T) {
+	for qps, want := range map[int]int{0: 10, 20: 20, 1000: _msgSendMaxQPS, 2e9: _msgSendMaxQPS} {
+		opt := &sendMessageToManyOption{qps: 10}
+		WithSendMessageToManyQPS(qps)(opt)
+		if opt.qps != want {
+			t.Errorf("WithSendMessageToManyQPS(%d): got %d, want %d", qps, opt.qps, want)
+		}
+	}
+}
+
+func Test_sendRateLimiter(t *testing.T) {
+	const window = 200 * time.Millisecond
+	ctx := context.Background()
+
+	// 每个窗口最多 5 次, 11 次请求需要跨越 2 个窗口
+	limiter := newSendRateLimiter(_msgSendMaxQPS, 5, window)
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		requireNil(t, limiter.wait(ctx))
+	}
+	if elapsed := time.Since(start); elapsed < 2*window {
+		t.Fatalf("11 requests took %s, want at least %s", elapsed, 2*window)
+	}
+
+	// 触发频率限制后等待至下一个窗口
+	limiter = newSendRateLimiter(_msgSendMaxQPS, 5, window)
+	requireNil(t, limiter.wait(ctx))
+	limiter.exhaust()
+	start = time.Now()
+	requireNil(t, limiter.wait(ctx))
+	if elapsed := time.Since(start); elapsed < window/2 {
+		t.Fatalf("retry after exhaust took %s, want to wait for the next window", elapsed)
+	}
+
+	// 每秒的间隔
+	limiter = newSendRateLimiter(10, 1000, time.Minute)
+	start = time.Now()
+	for i := 0; i < 4; i++ {
+		requireNil(t, limiter.wait(ctx))
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Fatalf("4 requests at 10 QPS took %s, want at least 300ms", elapsed)
+	}
+}
@@ -0,0 +1,122 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileType 上传文件的类型
+type FileType string
+
+const (
+	FileTypeOpus   FileType = "opus"   // 上传 OPUS 音频文件, 其他格式的音频文件，请转为 OPUS 格式后上传
+	FileTypeMp4    FileType = "mp4"    // 上传 MP4 格式视频文件
+	FileTypePdf    FileType = "pdf"    // 上传 PDF 格式文件
+	FileTypeDoc    FileType = "doc"    // 上传 DOC 格式文件
+	FileTypeXls    FileType = "xls"    // 上传 XLS 格式文件
+	FileTypePpt    FileType = "ppt"    // 上传 PPT 格式文件
+	FileTypeStream FileType = "stream" // 上传 stream 格式文件, 非以上类型的文件均使用该类型
+)
+
+// 名称: [消息与群组] 上传文件
+// Func: [api_messenger_files.go] UploadFile
+//
+// 描述: 上传文件，可以上传视频，音频和常见的文件类型
+// Info: 需要开启机器人能力
+// Info: 不允许上传空文件
+// Info: 文件大小不得超过30M
+// Info: 上传得到的 file_key 可用于 NewMessageFile, NewMessageAudio, NewMessageMedia
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/file/create
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/files
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+// 请求头: Content-Type=multipart/form-data; boundary=---7MA4YWxkTrZu0gW
+//
+type uploadFileResponse struct {
+	fsResponse
+
+	Data struct {
+		FileKey string `json:"file_key"`
+	} `json:"data"`
+}
+
+type UploadFileOption = doOption
+
+const _fileMaxSize = 30 << 20 // 上传文件最大不能超过30MB
+
+// WithUploadFile 通过文件路径上传, 带后缀的文件名取自 filename
+func WithUploadFile(filename string) UploadFileOption {
+	return func(opt *_doOpt) {
+		withDoUploadFormData("file_name", strings.NewReader(path.Base(filename)))(opt)
+		withDoUploadFormFile("file", filename, true, nil)(opt)
+	}
+}
+
+// WithUploadFileViaReader 通过 io.Reader 上传, filename 为带后缀的文件名
+func WithUploadFileViaReader(filename string, src io.Reader) UploadFileOption {
+	return func(opt *_doOpt) {
+		withDoUploadFormData("file_name", strings.NewReader(path.Base(filename)))(opt)
+		withDoUploadFormFile("file", filename, false, src)(opt)
+	}
+}
+
+// WithUploadFileDuration 音频、视频文件的时长
+//  上传 FileTypeOpus, FileTypeMp4 时建议填写, 精确到毫秒
+func WithUploadFileDuration(d time.Duration) UploadFileOption {
+	return withDoUploadFormData("duration", strings.NewReader(strconv.FormatInt(d.Milliseconds(), 10)))
+}
+
+func (a *app) UploadFile(fileType FileType, src UploadFileOption, opts ...UploadFileOption) (fileKey string, err error) {
+	return a.UploadFileWithContext(context.Background(), fileType, src, opts...)
+}
+
+func (a *app) UploadFileWithContext(ctx context.Context, fileType FileType, src UploadFileOption, opts ...UploadFileOption) (fileKey string, err error) {
+	apiDomain := "消息与群组"
+	apiName := "上传文件"
+	urlSuffix := "/open-apis/im/v1/files"
+
+	if !a.isSupported(true, true) {
+		return "", fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return "", err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	opts = append([]UploadFileOption{
+		withDoUploadFormData("file_type", strings.NewReader(string(fileType))),
+	}, opts...)
+	// 文件内容放在表单最后
+	opts = append(opts, src)
+	doOpts := a.buildOpts(apiDomain, apiName, header, opts...)
+	reqID, reader, err := a._doUploadWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	resp := new(uploadFileResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return "", err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return "", err
+	}
+
+	return resp.Data.FileKey, nil
+}
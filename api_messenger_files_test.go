@@ -0,0 +1,35 @@
+package feishu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_app_UploadFile(t *testing.T) {
+	fsApp := testNewCustomApp()
+
+	_, err := fsApp.getAppAccessTokenWithContext(context.Background())
+	requireNil(t, err)
+
+	fsApp.opt.debug = true
+
+	fileKey, err := fsApp.UploadFile(FileTypeStream, WithUploadFileViaReader("ci.log", strings.NewReader("first line\nsecond line\n")))
+	requireNil(t, err)
+
+	t.Log(fileKey)
+
+	uhDir, err := os.UserHomeDir()
+	requireNil(t, err)
+
+	fileKey, err = fsApp.UploadFile(FileTypeMp4,
+		WithUploadFile(filepath.Join(uhDir, "/Movies/demo.mp4")),
+		WithUploadFileDuration(3*time.Second),
+	)
+	requireNil(t, err)
+
+	t.Log(fileKey)
+}
@@ -0,0 +1,161 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+)
+
+// 名称: [消息与群组] 转发消息
+// Func: [api_messenger_forward.go] ForwardMessage
+//
+// 描述: 转发一条指定消息给用户或者会话
+// Info: 需要开启机器人能力
+// Info: 转发消息给用户，需要机器人对用户有可用性
+// Info: 转发消息给群组，需要机器人在群中
+// Info: 不支持转发合并转发消息、卡片消息中的 @ 等内容
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message/forward
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id/forward
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type forwardMessageRequest struct {
+	// 依据 receive_id_type 的值，填写对应的消息接收者id
+	ReceiveID string `json:"receive_id"`
+}
+
+func (a *app) ForwardMessage(messageID string, receiver MessageReceiver) (MessageDetail, error) {
+	return a.ForwardMessageWithContext(context.Background(), messageID, receiver)
+}
+
+func (a *app) ForwardMessageWithContext(ctx context.Context, messageID string, receiver MessageReceiver) (MessageDetail, error) {
+	apiDomain := "消息与群组"
+	apiName := "转发消息"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/forward", messageID)
+
+	if !a.isSupported(true, true) {
+		return MessageDetail{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &forwardMessageRequest{
+		ReceiveID: receiver.ID,
+	}
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageDetail{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header,
+		withDoQueryKV("receive_id_type", string(receiver.IDType)),
+	)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return MessageDetail{}, err
+	}
+
+	resp := new(sendMessageResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MessageDetail{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MessageDetail{}, err
+	}
+
+	return resp.Data, nil
+}
+
+// 名称: [消息与群组] 合并转发消息
+// Func: [api_messenger_forward.go] MergeForwardMessage
+//
+// 描述: 将来自同一个会话内的多条消息，合并转发给指定的用户或者会话
+// Info: 需要开启机器人能力
+// Info: 转发消息给用户，需要机器人对用户有可用性
+// Info: 转发消息给群组，需要机器人在群中
+// Info: 待转发的消息必须来自同一个会话
+// Info: 通过 获取指定消息的内容 查询合并转发消息时，子消息的 MessageDetail.UpperMessageID 为上一层级的消息 ID
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message/merge_forward
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/merge_forward
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type mergeForwardMessageRequest struct {
+	// 依据 receive_id_type 的值，填写对应的消息接收者id
+	ReceiveID string `json:"receive_id"`
+
+	// 要转发的消息ID列表，列表内的消息必须来自同一个会话
+	MessageIDList []string `json:"message_id_list"`
+}
+
+type mergeForwardMessageResponse struct {
+	fsResponse
+	Data MergeForwardResult `json:"data"`
+}
+
+type MergeForwardResult struct {
+	Message              MessageDetail `json:"message"`                 // 合并转发生成的新消息
+	InvalidMessageIDList []string      `json:"invalid_message_id_list"` // 无效的消息ID列表
+}
+
+func (a *app) MergeForwardMessage(receiver MessageReceiver, messageIDs []string) (MergeForwardResult, error) {
+	return a.MergeForwardMessageWithContext(context.Background(), receiver, messageIDs)
+}
+
+func (a *app) MergeForwardMessageWithContext(ctx context.Context, receiver MessageReceiver, messageIDs []string) (MergeForwardResult, error) {
+	apiDomain := "消息与群组"
+	apiName := "合并转发消息"
+	urlSuffix := "/open-apis/im/v1/messages/merge_forward"
+
+	if !a.isSupported(true, true) {
+		return MergeForwardResult{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &mergeForwardMessageRequest{
+		ReceiveID:     receiver.ID,
+		MessageIDList: messageIDs,
+	}
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MergeForwardResult{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header,
+		withDoQueryKV("receive_id_type", string(receiver.IDType)),
+	)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return MergeForwardResult{}, err
+	}
+
+	resp := new(mergeForwardMessageResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MergeForwardResult{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MergeForwardResult{}, err
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,43 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_ForwardMessage(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	receiver := MessageReceiver{
+		IDType: ChatID,
+		ID:     "oc_5f2c7c2066c6be483bb0302f2fa0c04f",
+	}
+
+	msgDetail, err := fsApp.ForwardMessage("om_d85801e64eb4135954cd657d4e11c8df", receiver)
+	requireNil(t, err)
+
+	logIndent(t, msgDetail)
+}
+
+func Test_app_MergeForwardMessage(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	receiver := MessageReceiver{
+		IDType: ChatID,
+		ID:     "oc_5f2c7c2066c6be483bb0302f2fa0c04f",
+	}
+
+	result, err := fsApp.MergeForwardMessage(receiver, []string{
+		"om_d85801e64eb4135954cd657d4e11c8df",
+		"om_7fbe1b0e1b9b6cd1c2f3a4d5e6f7a8b9",
+	})
+	requireNil(t, err)
+
+	logIndent(t, result)
+
+	items, err := fsApp.GetMessage(result.Message.MessageID)
+	requireNil(t, err)
+
+	logIndent(t, items)
+}
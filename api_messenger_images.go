@@ -1,9 +1,18 @@
 package feishu
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 	"io"
+	"os"
+	"path"
 	"strings"
 )
 
@@ -12,7 +21,9 @@ import (
 //
 // 描述: 上传图片接口，可以上传 JPEG、PNG、WEBP、GIF、TIFF、BMP、ICO格式图片
 // Info: 需要开启机器人能力
-// Info: 上传的图片大小不能超过10MB
+// Info: 上传的图片大小不能超过10MB，且不支持上传大小为0的图片
+// Info: GIF 图片分辨率不能超过 2000 x 2000，其他图片分辨率不能超过 12000 x 12000
+// Info: 上传前会根据图片内容识别格式并校验以上限制，上传的文件名后缀以识别出的格式为准
 //
 // Doc: https://open.feishu.cn/document/uAjLw4CM/ukTMukTMukTM/reference/im-v1/image/create
 //
@@ -33,6 +44,14 @@ type uploadImageResponse struct {
 	} `json:"data"`
 }
 
+// ImageType 上传图片的用途
+type ImageType string
+
+const (
+	ImageTypeMessage ImageType = "message" // 用于发送消息
+	ImageTypeAvatar  ImageType = "avatar"  // 用于设置头像
+)
+
 type UploadImageOption = doOption
 
 func WithUploadImage(filename string) UploadImageOption {
@@ -43,11 +62,244 @@ func WithUploadImageViaReader(filename string, src io.Reader) UploadImageOption
 	return withDoUploadFormFile("image", filename, false, src)
 }
 
-func (a *app) UploadImage(src UploadImageOption) (imageKey string, err error) {
-	return a.UploadImageWithContext(context.Background(), src)
+// WithUploadImageType 图片的用途, 默认 ImageTypeMessage
+func WithUploadImageType(imageType ImageType) UploadImageOption {
+	return withDoUploadFormValue("image_type", string(imageType))
+}
+
+var (
+	ErrImageEmpty             = errors.New("image is empty")
+	ErrImageTooLarge          = errors.New("image is too large")
+	ErrImageUnsupportedFormat = errors.New("unsupported image format")
+)
+
+type imageFormatLimit struct {
+	ext       string
+	maxSize   int64
+	maxWidth  int
+	maxHeight int
+}
+
+const _imageMaxSize = 10 << 20
+
+// 各图片格式的上传限制
+var _imageFormatLimits = map[string]imageFormatLimit{
+	"jpeg": {ext: ".jpg", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+	"png":  {ext: ".png", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+	"gif":  {ext: ".gif", maxSize: _imageMaxSize, maxWidth: 2000, maxHeight: 2000},
+	"webp": {ext: ".webp", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+	"tiff": {ext: ".tiff", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+	"bmp":  {ext: ".bmp", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+	"ico":  {ext: ".ico", maxSize: _imageMaxSize, maxWidth: 12000, maxHeight: 12000},
+}
+
+// detectImageFormat 根据图片内容识别格式
+//  JPEG、PNG、GIF 使用标准库解码器, 其余格式通过文件头识别并解析分辨率
+//  文件头不完整、无法获取分辨率时 width, height 为 0
+func detectImageFormat(data []byte) (format string, width, height int, err error) {
+	if cfg, format, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return format, cfg.Width, cfg.Height, nil
+	}
+
+	switch {
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		width, height = webpSize(data)
+		return "webp", width, height, nil
+	case len(data) >= 4 && (string(data[:4]) == "II*\x00" || string(data[:4]) == "MM\x00*"):
+		width, height = tiffSize(data)
+		return "tiff", width, height, nil
+	case len(data) >= 26 && string(data[:2]) == "BM":
+		width = int(int32(binary.LittleEndian.Uint32(data[18:22])))
+		height = int(int32(binary.LittleEndian.Uint32(data[22:26])))
+		if height < 0 {
+			// top-down DIB
+			height = -height
+		}
+		return "bmp", width, height, nil
+	case len(data) >= 4 && string(data[:4]) == "\x00\x00\x01\x00":
+		width, height = icoSize(data)
+		return "ico", width, height, nil
+	}
+
+	return "", 0, 0, ErrImageUnsupportedFormat
+}
+
+// webpSize 解析 WebP 第一个块(VP8/VP8L/VP8X)中的分辨率
+func webpSize(data []byte) (width, height int) {
+	if len(data) < 16 {
+		return 0, 0
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		// 有损: 3 字节帧标记, 3 字节起始码, 之后为 14 位的宽、高
+		if len(data) < 30 || string(data[23:26]) != "\x9d\x01\x2a" {
+			return 0, 0
+		}
+		return int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff), int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff)
+	case "VP8L":
+		// 无损: 1 字节签名, 之后为 14 位的宽-1、高-1
+		if len(data) < 25 || data[20] != 0x2f {
+			return 0, 0
+		}
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1
+	case "VP8X":
+		// 扩展: 4 字节标记, 之后为 24 位的画布宽-1、高-1
+		if len(data) < 30 {
+			return 0, 0
+		}
+		le24 := func(b []byte) int { return int(b[0]) | int(b[1])<<8 | int(b[2])<<16 }
+		return le24(data[24:27]) + 1, le24(data[27:30]) + 1
+	}
+	return 0, 0
+}
+
+// tiffSize 解析 TIFF 第一个 IFD 中的 ImageWidth(256)、ImageLength(257)
+func tiffSize(data []byte) (width, height int) {
+	if len(data) < 8 {
+		return 0, 0
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == 'M' {
+		order = binary.BigEndian
+	}
+	offset := int64(order.Uint32(data[4:8]))
+	if offset+2 > int64(len(data)) {
+		return 0, 0
+	}
+	n := int(order.Uint16(data[offset : offset+2]))
+	for i := 0; i < n; i++ {
+		entry := offset + 2 + int64(i)*12
+		if entry+12 > int64(len(data)) {
+			break
+		}
+		var value int
+		switch order.Uint16(data[entry+2 : entry+4]) {
+		case 3: // SHORT
+			value = int(order.Uint16(data[entry+8 : entry+10]))
+		case 4: // LONG
+			value = int(order.Uint32(data[entry+8 : entry+12]))
+		default:
+			continue
+		}
+		switch order.Uint16(data[entry : entry+2]) {
+		case 256:
+			width = value
+		case 257:
+			height = value
+		}
+	}
+	return width, height
+}
+
+// icoSize 解析 ICO 目录中最大的图标分辨率, 宽、高为 0 时表示 256
+func icoSize(data []byte) (width, height int) {
+	if len(data) < 6 {
+		return 0, 0
+	}
+	n := int(binary.LittleEndian.Uint16(data[4:6]))
+	for i := 0; i < n; i++ {
+		entry := 6 + i*16
+		if entry+16 > len(data) {
+			break
+		}
+		w, h := int(data[entry]), int(data[entry+1])
+		if w == 0 {
+			w = 256
+		}
+		if h == 0 {
+			h = 256
+		}
+		if w > width {
+			width = w
+		}
+		if h > height {
+			height = h
+		}
+	}
+	return width, height
 }
 
-func (a *app) UploadImageWithContext(ctx context.Context, src UploadImageOption) (imageKey string, err error) {
+// checkImage 识别图片格式并校验该格式的大小及分辨率限制
+func checkImage(data []byte) (limit imageFormatLimit, err error) {
+	if len(data) == 0 {
+		return imageFormatLimit{}, ErrImageEmpty
+	}
+
+	format, width, height, err := detectImageFormat(data)
+	if err != nil {
+		return imageFormatLimit{}, err
+	}
+	limit = _imageFormatLimits[format]
+
+	if int64(len(data)) > limit.maxSize {
+		return imageFormatLimit{}, fmt.Errorf("%w: %s: %d bytes (max: %d)", ErrImageTooLarge, format, len(data), limit.maxSize)
+	}
+	if width > limit.maxWidth || height > limit.maxHeight {
+		return imageFormatLimit{}, fmt.Errorf("%w: %s: %dx%d (max: %dx%d)", ErrImageTooLarge, format, width, height, limit.maxWidth, limit.maxHeight)
+	}
+
+	return limit, nil
+}
+
+// prepareUploadImage 读取待上传的图片并校验, 返回替换后的上传选项
+//  文件名后缀会修正为识别出的图片格式
+func prepareUploadImage(src UploadImageOption) (UploadImageOption, error) {
+	tmp := _newDoOpt(src)
+
+	var fd *_doFormData
+	for _, v := range tmp.uploadFormData {
+		if v.name == "image" {
+			fd = v
+		}
+	}
+	if fd == nil {
+		return nil, errors.New("missing image, use WithUploadImage or WithUploadImageViaReader")
+	}
+
+	var reader io.Reader = fd.data
+	if fd.needToReadFile {
+		f, err := os.Open(fd.filename)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		reader = f
+	}
+	if reader == nil {
+		return nil, ErrImageEmpty
+	}
+
+	// 多读取 1 字节用于判断是否超出限制
+	data, err := io.ReadAll(io.LimitReader(reader, _imageMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := checkImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := path.Base(fd.filename)
+	if ext := path.Ext(filename); !strings.EqualFold(ext, limit.ext) &&
+		!(limit.ext == ".jpg" && strings.EqualFold(ext, ".jpeg")) &&
+		!(limit.ext == ".tiff" && strings.EqualFold(ext, ".tif")) {
+		filename = strings.TrimSuffix(filename, ext) + limit.ext
+	}
+
+	return withDoUploadFormFile("image", filename, false, bytes.NewReader(data)), nil
+}
+
+// UploadImage 上传图片
+//  默认用于发送消息, 可通过 WithUploadImageType 指定图片用途
+func (a *app) UploadImage(src UploadImageOption, opts ...UploadImageOption) (imageKey string, err error) {
+	return a.UploadImageWithContext(context.Background(), src, opts...)
+}
+
+func (a *app) UploadImageWithContext(ctx context.Context, src UploadImageOption, opts ...UploadImageOption) (imageKey string, err error) {
 	apiDomain := "消息与群组"
 	apiName := "上传图片"
 	urlSuffix := "/open-apis/im/v1/images"
@@ -56,6 +308,10 @@ func (a *app) UploadImageWithContext(ctx context.Context, src UploadImageOption)
 		return "", fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
 	}
 
+	if src, err = prepareUploadImage(src); err != nil {
+		return "", fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
 	header := map[string]string{
 		"Authorization": "Bearer ",
 	}
@@ -64,10 +320,9 @@ func (a *app) UploadImageWithContext(ctx context.Context, src UploadImageOption)
 	} else {
 		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
 	}
-	doOpts := a.buildOpts(apiDomain, apiName, header,
-		withDoUploadFormData("image_type", strings.NewReader("message")),
-		src,
-	)
+	opts = append([]UploadImageOption{WithUploadImageType(ImageTypeMessage)}, opts...)
+	opts = append(opts, src)
+	doOpts := a.buildOpts(apiDomain, apiName, header, opts...)
 	reqID, reader, err := a._doUploadWithContext(ctx, urlSuffix, doOpts...)
 	if err != nil {
 		return "", err
@@ -84,3 +339,51 @@ func (a *app) UploadImageWithContext(ctx context.Context, src UploadImageOption)
 
 	return resp.Data.ImageKey, nil
 }
+
+// 名称: [消息与群组] 下载图片
+// Func: [api_messenger_images.go] DownloadImage
+//
+// 描述: 下载图片资源，只能下载应用自己上传且图片类型为 message 的图片
+// Info: 需要开启机器人能力
+// Info: 下载用户发送的图片请使用 GetMessageResource
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/image/get
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/images/:image_key
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+
+func (a *app) DownloadImage(imageKey string) (MessageResource, error) {
+	return a.DownloadImageWithContext(context.Background(), imageKey)
+}
+
+func (a *app) DownloadImageWithContext(ctx context.Context, imageKey string) (MessageResource, error) {
+	apiDomain := "消息与群组"
+	apiName := "下载图片"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/images/%s", imageKey)
+
+	if !a.isSupported(true, true) {
+		return MessageResource{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageResource{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, resp, err := a._getStreamWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return MessageResource{}, err
+	}
+
+	return a._newMessageResource(apiDomain, apiName, reqID, resp)
+}
@@ -1,7 +1,16 @@
 package feishu
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -30,3 +39,147 @@ func Test_app_UploadImage(t *testing.T) {
 
 	t.Log(imgKey)
 }
+
+func Test_app_DownloadImage(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	res, err := fsApp.DownloadImage("img_7ea74629-9191-4176-998c-2e603c9c5e8g")
+	requireNil(t, err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	n, err := io.Copy(io.Discard, res.Body)
+	requireNil(t, err)
+
+	t.Log(res.ContentType, n)
+}
+
+func Test_checkImage(t *testing.T) {
+	encode := func(format string, w, h int) []byte {
+		buf := new(bytes.Buffer)
+		img := image.NewPaletted(image.Rect(0, 0, w, h), color.Palette{color.Black, color.White})
+		var err error
+		switch format {
+		case "png":
+			err = png.Encode(buf, img)
+		case "gif":
+			err = gif.Encode(buf, img, nil)
+		case "jpeg":
+			err = jpeg.Encode(buf, img, nil)
+		}
+		requireNil(t, err)
+		return buf.Bytes()
+	}
+
+	bmp := make([]byte, 26)
+	copy(bmp, "BM")
+	binary.LittleEndian.PutUint32(bmp[18:], 640)
+	binary.LittleEndian.PutUint32(bmp[22:], uint32(0xFFFFFFFF-480+1)) // -480
+
+	webp := func(chunk string, payload ...byte) []byte {
+		return append([]byte("RIFF\x00\x00\x00\x00WEBP"+chunk+"\x00\x00\x00\x00"), payload...)
+	}
+	// 14 位的宽、高
+	vp8 := webp("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0xe0, 0x2e, 0x10, 0x00)
+	vp8Large := webp("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0xe1, 0x2e, 0x10, 0x00)
+	// 宽-1=99, 高-1=49
+	vp8l := webp("VP8L", 0x2f, 0x63, 0x40, 0x0c, 0x00)
+	vp8x := webp("VP8X", 0, 0, 0, 0, 0x7f, 0x02, 0, 0xdf, 0x01, 0)
+
+	tiff := make([]byte, 8+2+2*12)
+	copy(tiff, "MM\x00*")
+	binary.BigEndian.PutUint32(tiff[4:], 8)
+	binary.BigEndian.PutUint16(tiff[8:], 2)
+	for i, entry := range [][3]uint32{{256, 4, 12001}, {257, 3, 300}} {
+		off := 10 + i*12
+		binary.BigEndian.PutUint16(tiff[off:], uint16(entry[0]))
+		binary.BigEndian.PutUint16(tiff[off+2:], uint16(entry[1]))
+		binary.BigEndian.PutUint32(tiff[off+4:], 1)
+		if entry[1] == 3 {
+			binary.BigEndian.PutUint16(tiff[off+8:], uint16(entry[2]))
+		} else {
+			binary.BigEndian.PutUint32(tiff[off+8:], entry[2])
+		}
+	}
+
+	ico := []byte{0, 0, 1, 0, 2, 0}
+	ico = append(ico, append([]byte{32, 48}, make([]byte, 14)...)...)
+	ico = append(ico, append([]byte{0, 0}, make([]byte, 14)...)...)
+
+	for _, tt := range []struct {
+		name          string
+		data          []byte
+		format        string
+		width, height int
+	}{
+		{"vp8", vp8, "webp", 12000, 16},
+		{"vp8l", vp8l, "webp", 100, 50},
+		{"vp8x", vp8x, "webp", 640, 480},
+		{"tiff", tiff, "tiff", 12001, 300},
+		{"ico", ico, "ico", 256, 256},
+		{"bmp", bmp, "bmp", 640, 480},
+	} {
+		format, width, height, err := detectImageFormat(tt.data)
+		requireNil(t, err)
+		if format != tt.format || width != tt.width || height != tt.height {
+			t.Errorf("%s: detectImageFormat() = %s %dx%d, want %s %dx%d", tt.name, format, width, height, tt.format, tt.width, tt.height)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantExt string
+		wantErr error
+	}{
+		{"png", encode("png", 10, 10), ".png", nil},
+		{"jpeg", encode("jpeg", 10, 10), ".jpg", nil},
+		{"gif", encode("gif", 10, 10), ".gif", nil},
+		{"gif too large", encode("gif", 2001, 1), "", ErrImageTooLarge},
+		{"png large", encode("png", 2001, 1), ".png", nil},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), ".webp", nil},
+		{"webp vp8", vp8, ".webp", nil},
+		{"webp too large", vp8Large, "", ErrImageTooLarge},
+		{"tiff too large", tiff, "", ErrImageTooLarge},
+		{"ico", ico, ".ico", nil},
+		{"bmp", bmp, ".bmp", nil},
+		{"empty", nil, "", ErrImageEmpty},
+		{"text", []byte("not an image"), "", ErrImageUnsupportedFormat},
+		{"oversize", append(encode("png", 1, 1), make([]byte, _imageMaxSize)...), "", ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, err := checkImage(tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkImage() error = %v, want %v", err, tt.wantErr)
+			}
+			if limit.ext != tt.wantExt {
+				t.Fatalf("checkImage() ext = %q, want %q", limit.ext, tt.wantExt)
+			}
+		})
+	}
+}
+
+func Test_prepareUploadImage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := png.Encode(buf, image.NewGray(image.Rect(0, 0, 4, 4)))
+	requireNil(t, err)
+
+	src, err := prepareUploadImage(WithUploadImageViaReader("screenshot.jpg", buf))
+	requireNil(t, err)
+
+	opt := _newDoOpt(src)
+	if len(opt.uploadFormData) != 1 {
+		t.Fatalf("unexpected form data: %d", len(opt.uploadFormData))
+	}
+	if got := opt.uploadFormData[0].filename; got != "screenshot.png" {
+		t.Fatalf("filename = %q, want %q", got, "screenshot.png")
+	}
+
+	opt = _newDoOpt(WithUploadImageType(ImageTypeMessage), WithUploadImageType(ImageTypeAvatar))
+	if len(opt.uploadFormData) != 1 {
+		t.Fatalf("image_type should be replaced, got %d form data", len(opt.uploadFormData))
+	}
+}
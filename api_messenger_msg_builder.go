@@ -76,28 +76,36 @@ func MentionByOpenID(id string) string {
 // ⬇️ ---------------------------------------- 富文本 post ---------------------------------------- ⬇️
 
 type i18nPost struct {
-	lang     string        // 富文本的语言环境
-	title    string        // 富文本标题
-	elements []interface{} // 段落的所有元素
+	lang     string          // 富文本的语言环境
+	title    string          // 富文本标题
+	elements [][]interface{} // 段落的所有元素
 }
 
 type Post func() i18nPost
 
 // WithPost 富文本消息, 可指定语言环境
+//  所有元素放在同一个段落, 需要换行时使用 WithPostParagraphs
 //  支持元素如下:
 //  普通文本: WithPostElementText
+//  带样式的文本: WithPostElementStyledText
 //  文字超链接: WithPostElementLink
+//  带样式的文字超链接: WithPostElementStyledLink
 //  图片: WithPostElementImage
 //  @所有人: WithPostElementMentionAll
 //  @指定用户(OpenID): WithPostElementMentionByOpenID
+//  表情: WithPostElementEmotion
+//  视频: WithPostElementMedia
+//  代码块: WithPostElementCodeBlock
+//  分割线: WithPostElementHorizontalRule
+//  Markdown: WithPostElementMarkdown
 func WithPost(lang Language, title string, elements ...PostElement) Post {
 	return func() i18nPost {
-		es := make([]interface{}, 0, len(elements))
+		es := make([][]interface{}, 0, 1)
 		p := make([]interface{}, 0, len(elements))
 		for _, fn := range elements {
 			elem := fn()
-			if elem.isImage {
-				// 图片元素必须是独立的一个段落
+			if elem.isBlock {
+				// 图片等元素必须是独立的一个段落
 				es = append(es, p, []interface{}{elem.elem})
 				p = make([]interface{}, 0, len(elements))
 			} else {
@@ -113,55 +121,133 @@ func WithPost(lang Language, title string, elements ...PostElement) Post {
 	}
 }
 
+type PostParagraph func() []postElement
+
+// WithPostParagraphs 富文本消息, 可指定语言环境, 每个 PostParagraph 为一个段落（一行）
+//  图片、视频、代码块、分割线、Markdown 元素总是独立成段, 会将所在段落拆分
+func WithPostParagraphs(lang Language, title string, paragraphs ...PostParagraph) Post {
+	return func() i18nPost {
+		es := make([][]interface{}, 0, len(paragraphs))
+		for _, fn := range paragraphs {
+			es = appendPostParagraph(es, fn())
+		}
+		return i18nPost{
+			lang:     string(lang),
+			title:    title,
+			elements: es,
+		}
+	}
+}
+
+// WithPostParagraph 富文本消息的一个段落
+func WithPostParagraph(elements ...PostElement) PostParagraph {
+	return func() []postElement {
+		p := make([]postElement, 0, len(elements))
+		for _, fn := range elements {
+			p = append(p, fn())
+		}
+		return p
+	}
+}
+
+// appendPostParagraph 追加一个段落, 独立成段的元素拆分为单独的段落
+//  拆分产生的空段落会被忽略, 但保留显式的空段落
+func appendPostParagraph(es [][]interface{}, elements []postElement) [][]interface{} {
+	if len(elements) == 0 {
+		return append(es, []interface{}{})
+	}
+	p := make([]interface{}, 0, len(elements))
+	for _, elem := range elements {
+		if !elem.isBlock {
+			p = append(p, elem.elem)
+			continue
+		}
+		if len(p) != 0 {
+			es = append(es, p)
+			p = make([]interface{}, 0, len(elements))
+		}
+		es = append(es, []interface{}{elem.elem})
+	}
+	if len(p) != 0 {
+		es = append(es, p)
+	}
+	return es
+}
+
 type postElement struct {
-	elem    interface{}
-	isImage bool // 图片元素必须是独立的一个段落
+	elem    interface{} // postText, postLink, postAt, postImage, postEmotion, postMedia, postCodeBlock, postHR, postMarkdown
+	isBlock bool        // 图片、视频、代码块、分割线、Markdown 元素必须是独立的一个段落
 }
 
 type PostElement func() postElement
 
+// PostTextStyle 富文本消息的文本样式
+type PostTextStyle string
+
+const (
+	PostTextBold        PostTextStyle = "bold"
+	PostTextUnderline   PostTextStyle = "underline"
+	PostTextLineThrough PostTextStyle = "lineThrough"
+	PostTextItalic      PostTextStyle = "italic"
+)
+
 // WithPostElementText 富文本消息的文字元素
 //  isUnescape 表示是不是 unescape 解码，默认为 false ，不用可以不填
 func WithPostElementText(text string, isUnescape ...bool) PostElement {
 	return func() postElement {
-		elem := map[string]interface{}{
-			"tag":  "text",
-			"text": text,
-		}
+		elem := postText{Tag: "text", Text: text}
 		if len(isUnescape) != 0 && isUnescape[0] {
-			elem["un_escape"] = isUnescape[0]
-		}
-		return postElement{
-			elem:    elem,
-			isImage: false,
+			elem.UnEscape = isUnescape[0]
 		}
+		return postElement{elem: elem}
+	}
+}
+
+// WithPostElementStyledText 富文本消息的带样式文字元素
+//  WithPostElementStyledText("text", PostTextBold, PostTextItalic)
+func WithPostElementStyledText(text string, style ...PostTextStyle) PostElement {
+	return func() postElement {
+		return newPostElementText(text, style)
+	}
+}
+
+func newPostElementText(text string, style []PostTextStyle) postElement {
+	return postElement{
+		elem: postText{Tag: "text", Text: text, Style: style},
 	}
 }
 
 // WithPostElementLink 富文本消息的文字超链接元素
 func WithPostElementLink(text, href string) PostElement {
 	return func() postElement {
-		return postElement{
-			elem: map[string]interface{}{
-				"tag":  "a",
-				"text": text,
-				"href": href,
-			},
-			isImage: false,
-		}
+		return newPostElementLink(text, href, nil)
+	}
+}
+
+// WithPostElementStyledLink 富文本消息的带样式文字超链接元素
+func WithPostElementStyledLink(text, href string, style ...PostTextStyle) PostElement {
+	return func() postElement {
+		return newPostElementLink(text, href, style)
+	}
+}
+
+func newPostElementLink(text, href string, style []PostTextStyle) postElement {
+	return postElement{
+		elem: postLink{Tag: "a", Text: text, Href: href, Style: style},
 	}
 }
 
 // WithPostElementImage 富文本消息的图片元素
 func WithPostElementImage(imgKey string) PostElement {
 	return func() postElement {
-		return postElement{
-			elem: map[string]interface{}{
-				"tag":       "img",
-				"image_key": imgKey,
-			},
-			isImage: true,
-		}
+		return newPostElementImage(imgKey)
+	}
+}
+
+func newPostElementImage(imgKey string) postElement {
+	return postElement{
+		elem:    postImage{Tag: "img", ImageKey: imgKey},
+		isBlock: true,
 	}
 }
 
@@ -169,11 +255,7 @@ func WithPostElementImage(imgKey string) PostElement {
 func WithPostElementMentionAll() PostElement {
 	return func() postElement {
 		return postElement{
-			elem: map[string]interface{}{
-				"tag":     "at",
-				"user_id": "all",
-			},
-			isImage: false,
+			elem: postAt{Tag: "at", UserID: "all"},
 		}
 	}
 }
@@ -182,16 +264,68 @@ func WithPostElementMentionAll() PostElement {
 //  Open ID 必须是有效值，否则仅显示 `@` 符号（实际效果不同于 PushText 时会显示 name）
 func WithPostElementMentionByOpenID(id string, name ...string) PostElement {
 	return func() postElement {
-		elem := map[string]interface{}{
-			"tag":     "at",
-			"user_id": id,
-		}
+		elem := postAt{Tag: "at", UserID: id}
 		if len(name) != 0 {
-			elem["user_name"] = name[0]
+			elem.UserName = name[0]
 		}
+		return postElement{elem: elem}
+	}
+}
+
+// WithPostElementEmotion 富文本消息的表情元素
+//  表情类型同消息表情回复, 参见 EmojiType
+func WithPostElementEmotion(emojiType EmojiType) PostElement {
+	return func() postElement {
 		return postElement{
-			elem:    elem,
-			isImage: false,
+			elem: postEmotion{Tag: "emotion", EmojiType: emojiType},
+		}
+	}
+}
+
+// WithPostElementMedia 富文本消息的视频元素
+//  fileKey 通过 UploadFile 上传 mp4 文件获取, imageKey 为视频封面图片（可为空）
+func WithPostElementMedia(fileKey string, imageKey ...string) PostElement {
+	return func() postElement {
+		elem := postMedia{Tag: "media", FileKey: fileKey}
+		if len(imageKey) != 0 {
+			elem.ImageKey = imageKey[0]
+		}
+		return postElement{elem: elem, isBlock: true}
+	}
+}
+
+// WithPostElementCodeBlock 富文本消息的代码块元素
+//  language 不区分大小写, 如 GO、PYTHON、JSON、SHELL, 为空时不高亮
+func WithPostElementCodeBlock(language, text string) PostElement {
+	return func() postElement {
+		return newPostElementCodeBlock(language, text)
+	}
+}
+
+func newPostElementCodeBlock(language, text string) postElement {
+	return postElement{
+		elem:    postCodeBlock{Tag: "code_block", Language: language, Text: text},
+		isBlock: true,
+	}
+}
+
+// WithPostElementHorizontalRule 富文本消息的分割线元素
+func WithPostElementHorizontalRule() PostElement {
+	return func() postElement {
+		return postElement{
+			elem:    postHR{Tag: "hr"},
+			isBlock: true,
+		}
+	}
+}
+
+// WithPostElementMarkdown 富文本消息的 Markdown 元素
+//  仅支持部分语法, 语法详情: https://open.feishu.cn/document/server-docs/im-v1/message-content-description/create_json#45e0953e
+func WithPostElementMarkdown(text string) PostElement {
+	return func() postElement {
+		return postElement{
+			elem:    postMarkdown{Tag: "md", Text: text},
+			isBlock: true,
 		}
 	}
 }
@@ -217,16 +351,22 @@ type Card func() i18nCard
 //  分割线: WithCardElementHorizontalRule
 //  图片: WithCardElementImage
 //  备注: WithCardElementNote
+//  多列布局: WithCardElementColumnSet
+//  折叠面板: WithCardElementCollapsiblePanel
+//  表单: WithCardElementForm
+//  表格: WithCardElementTable
+//  图表: WithCardElementChart
+//  人员: WithCardElementPerson, WithCardElementPersonList, WithCardElementAvatar
 func WithCard(lang Language, title string, elem CardElement, elements ...CardElement) Card {
 	elements = append([]CardElement{elem}, elements...)
-	es := make([]interface{}, 0, len(elements))
-	for _, fn := range elements {
-		if fn == nil {
-			continue
-		}
-		es = append(es, fn(false))
-	}
 	return func() i18nCard {
+		es := make([]interface{}, 0, len(elements))
+		for _, fn := range elements {
+			if fn == nil {
+				continue
+			}
+			es = append(es, fn(false))
+		}
 		return i18nCard{
 			lang:     string(lang),
 			title:    title,
@@ -290,21 +430,25 @@ func defaultMsgCardHeader() *msgCardHeader {
 }
 
 type cardConfig struct {
-	mCfg      map[string]interface{}
-	mCardLink map[string]string
+	mCfg      *msgCardConfig
+	mCardLink *msgCardLink
 }
 
 type CardConfig func() cardConfig
 
 type CardConfigOption func(*cardConfig)
 
+func (cfg *cardConfig) config() *msgCardConfig {
+	if cfg.mCfg == nil {
+		cfg.mCfg = new(msgCardConfig)
+	}
+	return cfg.mCfg
+}
+
 // WithCardConfigEnableForward 设置是否允许卡片被转发, 默认允许转发
 func WithCardConfigEnableForward(b bool) CardConfigOption {
 	return func(cfg *cardConfig) {
-		if cfg.mCfg == nil {
-			cfg.mCfg = make(map[string]interface{}, 2)
-		}
-		cfg.mCfg["enable_forward"] = b
+		cfg.config().EnableForward = &b
 	}
 }
 
@@ -313,26 +457,50 @@ func WithCardConfigEnableForward(b bool) CardConfigOption {
 //  false: 是独享卡片，仅操作用户可见卡片的更新内容。
 func WithCardConfigEnableUpdateMulti(b bool) CardConfigOption {
 	return func(cfg *cardConfig) {
-		if cfg.mCfg == nil {
-			cfg.mCfg = make(map[string]interface{}, 2)
+		cfg.config().UpdateMulti = &b
+	}
+}
+
+// CardWidthMode 卡片宽度模式, 仅 JSON 2.0 卡片生效
+//  default: 默认宽度, PC 端宽版、iPad 端上的宽度上限为 600px
+//  compact: 紧凑宽度, 宽度为 400px
+//  fill: 撑满聊天窗口宽度
+type CardWidthMode string
+
+const (
+	CardWidthDefault CardWidthMode = "default"
+	CardWidthCompact CardWidthMode = "compact"
+	CardWidthFill    CardWidthMode = "fill"
+)
+
+// WithCardConfigWidthMode 设置卡片宽度模式, 仅 JSON 2.0 卡片生效
+func WithCardConfigWidthMode(mode CardWidthMode) CardConfigOption {
+	return func(cfg *cardConfig) {
+		cfg.config().WidthMode = string(mode)
+	}
+}
+
+// WithCardConfigStreamingMode 设置卡片是否处于流式更新模式, 仅 JSON 2.0 卡片生效
+//  summary: 流式更新时会话列表中展示的摘要, 为空时不指定
+func WithCardConfigStreamingMode(b bool, summary ...string) CardConfigOption {
+	return func(cfg *cardConfig) {
+		c := cfg.config()
+		c.StreamingMode = &b
+		if len(summary) != 0 && summary[0] != "" {
+			c.Summary = &msgCardSummary{Content: summary[0]}
 		}
-		cfg.mCfg["update_multi"] = b
 	}
 }
 
 // WithCardConfigCardLink 设置卡片的多端跳转链接
 func WithCardConfigCardLink(url, android, ios, pc string) CardConfigOption {
 	return func(cfg *cardConfig) {
-		if cfg.mCardLink == nil {
-			cfg.mCardLink = make(map[string]string, 4)
-		}
-		cfg.mCardLink = map[string]string{
-			"url":         url,
-			"android_url": android,
-			"ios_url":     ios,
-			"pc_url":      pc,
+		cfg.mCardLink = &msgCardLink{
+			URL:        url,
+			AndroidURL: android,
+			IOSURL:     ios,
+			PCURL:      pc,
 		}
-
 	}
 }
 
@@ -340,6 +508,8 @@ func WithCardConfigCardLink(url, android, ios, pc string) CardConfigOption {
 //  - 是否允许卡片消息被转发, 默认值: true WithCardConfigEnableForward
 //  - 是否为共享卡片, 默认值: false WithCardConfigEnableUpdateMulti
 //  - 设置卡片跳转链接 WithCardConfigCardLink
+//  - 卡片宽度模式（JSON 2.0） WithCardConfigWidthMode
+//  - 流式更新模式（JSON 2.0） WithCardConfigStreamingMode
 func WithCardConfig(opt CardConfigOption, opts ...CardConfigOption) CardConfig {
 	opts = append([]CardConfigOption{opt}, opts...)
 	var ret cardConfig
@@ -357,21 +527,20 @@ type CardElement func(isEmbedded bool) interface{}
 //  lines: 内容显示行数
 func WithCardElementPlainText(text string, lines ...int) CardElement {
 	return func(isEmbedded bool) interface{} {
-		sub := map[string]interface{}{
-			"tag":     "plain_text",
-			"content": text,
+		sub := cardText{
+			Tag:     "plain_text",
+			Content: text,
 		}
 		if len(lines) != 0 && lines[0] > 0 {
-			sub["lines"] = lines[0]
+			sub.Lines = lines[0]
 		}
 		if isEmbedded {
-			return sub
+			return &sub
 		}
-		elem := map[string]interface{}{
-			"tag":  "div",
-			"text": sub,
+		return &cardDiv{
+			Tag:  "div",
+			Text: &sub,
 		}
-		return elem
 	}
 }
 
@@ -379,8 +548,7 @@ type CardExtraElement func() (key string, v interface{})
 
 func WithCardExtraElementImage(imgKey string, opts ...CardElemImageOption) CardExtraElement {
 	return func() (key string, v interface{}) {
-		key, v = "extra", WithCardElementImage(imgKey, opts...)(true)
-		return
+		return "extra", WithCardElementImage(imgKey, opts...)(true)
 	}
 }
 
@@ -388,21 +556,23 @@ func WithCardExtraElementImage(imgKey string, opts ...CardElemImageOption) CardE
 //  语法仅支持部分, 语法详情: https://open.feishu.cn/document/ukTMukTMukTM/uADOwUjLwgDM14CM4ATN
 func WithCardElementMarkdown(md string, extra ...CardExtraElement) CardElement {
 	return func(isEmbedded bool) interface{} {
-		sub := map[string]interface{}{
-			"tag":     "lark_md",
-			"content": md,
+		sub := cardText{
+			Tag:     "lark_md",
+			Content: md,
 		}
 		if isEmbedded {
-			return sub
+			return &sub
 		}
 
-		elem := map[string]interface{}{
-			"tag":  "div",
-			"text": sub,
+		elem := &cardDiv{
+			Tag:  "div",
+			Text: &sub,
 		}
 		for _, fn := range extra {
-			k, v := fn()
-			elem[k] = v
+			if fn == nil {
+				continue
+			}
+			elem.set(fn())
 		}
 		return elem
 	}
@@ -412,9 +582,9 @@ type CardElementField func() interface{}
 
 func WithCardElementField(elem CardElement, isShort bool) CardElementField {
 	return func() interface{} {
-		return map[string]interface{}{
-			"text":     elem(true),
-			"is_short": isShort,
+		return &cardField{
+			Text:    elem(true),
+			IsShort: isShort,
 		}
 	}
 }
@@ -425,18 +595,17 @@ func WithCardElementField(elem CardElement, isShort bool) CardElementField {
 //  - WithCardElementMarkdown
 func WithCardElementFields(f CardElementField, fields ...CardElementField) CardElement {
 	fields = append([]CardElementField{f}, fields...)
-	fs := make([]interface{}, 0, len(fields))
-	for _, fn := range fields {
-		if fn == nil {
-			continue
-		}
-		fs = append(fs, fn())
-	}
-
 	return func(bool) interface{} {
-		return map[string]interface{}{
-			"tag":    "div",
-			"fields": fs,
+		fs := make([]interface{}, 0, len(fields))
+		for _, fn := range fields {
+			if fn == nil {
+				continue
+			}
+			fs = append(fs, fn())
+		}
+		return &cardDiv{
+			Tag:    "div",
+			Fields: fs,
 		}
 	}
 }
@@ -453,53 +622,54 @@ type CardElementActionOption func() (key string, v interface{})
 
 func WithCardElementActionButton(btn ElementButton) CardElementActionOption {
 	return func() (key string, v interface{}) {
-		key, v = "type", string(btn)
-		return
+		return "type", string(btn)
 	}
 }
 
 func WithCardElementActionMultiURL(url, android, ios, pc string) CardElementActionOption {
 	return func() (key string, v interface{}) {
-		key, v = "multi_url", map[string]string{
-			"url":         url,
-			"android_url": android,
-			"ios_url":     ios,
-			"pc_url":      pc,
+		return "multi_url", &msgCardLink{
+			URL:        url,
+			AndroidURL: android,
+			IOSURL:     ios,
+			PCURL:      pc,
 		}
-		return
 	}
 }
 
 type CardElementAction func() interface{}
 
+// WithCardElementAction 按钮
+//  url 为空时不跳转, 点击后回调 WithCardElementActionValue 指定的 value
 func WithCardElementAction(elem CardElement, url string, opts ...CardElementActionOption) CardElementAction {
-	ret := map[string]interface{}{
-		"tag":  "button",
-		"text": elem(true),
-		"url":  url,
-	}
-	for _, fn := range opts {
-		k, v := fn()
-		ret[k] = v
-	}
-
 	return func() interface{} {
-		return ret
+		return buildCardInteractive(&cardInteractive{
+			Tag:  "button",
+			Text: elem(true),
+			URL:  url,
+		}, opts)
 	}
 }
 
-// WithCardElementActions 按钮, 可指定但固定跳转, 或多端跳转
+// WithCardElementActions 交互模块, 可指定但固定跳转, 或多端跳转
+//  支持元素:
+//  - WithCardElementAction
+//  - WithCardElementSelectStatic
+//  - WithCardElementSelectPerson
+//  - WithCardElementOverflow
+//  - WithCardElementDatePicker
+//  - WithCardElementTimePicker
+//  - WithCardElementDatetimePicker
 func WithCardElementActions(act CardElementAction, actions ...CardElementAction) CardElement {
 	actions = append([]CardElementAction{act}, actions...)
-	as := make([]interface{}, 0, len(actions))
-	for _, fn := range actions {
-		as = append(as, fn())
-	}
-
 	return func(bool) interface{} {
-		return map[string]interface{}{
-			"tag":     "action",
-			"actions": as,
+		as := make([]interface{}, 0, len(actions))
+		for _, fn := range actions {
+			as = append(as, fn())
+		}
+		return &cardAction{
+			Tag:     "action",
+			Actions: as,
 		}
 	}
 }
@@ -507,9 +677,7 @@ func WithCardElementActions(act CardElementAction, actions ...CardElementAction)
 // WithCardElementHorizontalRule 分割线
 func WithCardElementHorizontalRule() CardElement {
 	return func(bool) interface{} {
-		return map[string]interface{}{
-			"tag": "hr",
-		}
+		return &cardHR{Tag: "hr"}
 	}
 }
 
@@ -519,11 +687,10 @@ type CardElemImageOption func() (key string, v interface{})
 //  仅支持普通文本格式
 func WithCardElementImageHover(text string) CardElemImageOption {
 	return func() (key string, v interface{}) {
-		key, v = "alt", map[string]interface{}{
-			"tag":     "plain_text",
-			"content": text,
+		return "alt", cardText{
+			Tag:     "plain_text",
+			Content: text,
 		}
-		return
 	}
 }
 
@@ -540,11 +707,10 @@ func WithCardElementImageTitle(text string, md ...bool) CardElemImageOption {
 		if isMD {
 			tag, text = "lark_md", trimPrefixSpace(text)
 		}
-		key, v = "title", map[string]interface{}{
-			"tag":     tag,
-			"content": text,
+		return "title", &cardText{
+			Tag:     tag,
+			Content: text,
 		}
-		return
 	}
 }
 
@@ -577,26 +743,17 @@ const (
 //  ImageModeFitHorizontal：平铺模式，宽度撑满卡片完整展示上传的图片。该属性会覆盖custom_width 属性
 func WithCardElementImageMode(mode ImageMode) CardElemImageOption {
 	return func() (key string, v interface{}) {
-		key, v = "mode", string(mode)
-		return
+		return "mode", string(mode)
 	}
 }
 
 // WithCardElementImageCustomWidth 自定义图片的最大展示宽度
 //  默认展示宽度撑满卡片的通栏图片
-//  可在 278px~580px 范围内指定最大展示宽度
+//  可在 278px~580px 范围内指定最大展示宽度, 超出范围时取最接近的值, Message.Validate 会返回错误
 //  在飞书4.0以上版本生效
 func WithCardElementImageCustomWidth(w int) CardElemImageOption {
-	min, max := 278, 580
-	if w < min {
-		w = min
-	}
-	if w > max {
-		w = max
-	}
 	return func() (key string, v interface{}) {
-		key, v = "custom_width", w
-		return
+		return "custom_width", w
 	}
 }
 
@@ -605,8 +762,7 @@ func WithCardElementImageCustomWidth(w int) CardElemImageOption {
 //  若配置为 true，则展示最大宽度为278px的紧凑型图片
 func WithCardElementImageCompactWidth(b bool) CardElemImageOption {
 	return func() (key string, v interface{}) {
-		key, v = "compact_width", b
-		return
+		return "compact_width", b
 	}
 }
 
@@ -615,27 +771,24 @@ func WithCardElementImageCompactWidth(b bool) CardElemImageOption {
 //  在配置 card_link 后可设置为false，使用户点击卡片上的图片也能响应card_link链接跳转
 func WithCardElementImagePreview(b bool) CardElemImageOption {
 	return func() (key string, v interface{}) {
-		key, v = "preview", b
-		return
+		return "preview", b
 	}
 }
 
 func WithCardElementImage(imgKey string, opts ...CardElemImageOption) CardElement {
-	// hover 默认为空，不展示
-	opts = append([]CardElemImageOption{WithCardElementImageHover("")}, opts...)
-
 	return func(bool) interface{} {
-		elem := map[string]interface{}{
-			"tag":     "img",
-			"img_key": imgKey,
+		elem := &cardImage{
+			Tag:    "img",
+			ImgKey: imgKey,
+			// hover 默认为空，不展示
+			Alt: cardText{Tag: "plain_text"},
 		}
-
 		for _, fn := range opts {
-			if k, v := fn(); v != nil {
-				elem[k] = v
+			if fn == nil {
+				continue
 			}
+			elem.set(fn())
 		}
-
 		return elem
 	}
 }
@@ -647,14 +800,14 @@ func WithCardElementImage(imgKey string, opts ...CardElemImageOption) CardElemen
 //  - WithCardElementImage
 func WithCardElementNote(elem CardElement, elements ...CardElement) CardElement {
 	elements = append([]CardElement{elem}, elements...)
-	es := make([]interface{}, 0, len(elements))
-	for _, fn := range elements {
-		es = append(es, fn(true))
-	}
 	return func(bool) interface{} {
-		return map[string]interface{}{
-			"tag":      "note",
-			"elements": es,
+		es := make([]interface{}, 0, len(elements))
+		for _, fn := range elements {
+			es = append(es, fn(true))
+		}
+		return &cardNote{
+			Tag:      "note",
+			Elements: es,
 		}
 	}
 }
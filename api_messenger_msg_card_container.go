@@ -0,0 +1,300 @@
+package feishu
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrCardElementNotAllowed 容器中包含不支持的卡片元素
+var ErrCardElementNotAllowed = errors.New("card element not allowed in container")
+
+// invalidCardElement 无效的卡片元素, 序列化时返回错误
+type invalidCardElement struct {
+	err error
+}
+
+func (e invalidCardElement) MarshalJSON() ([]byte, error) {
+	return nil, e.err
+}
+
+// cardElementError 返回卡片元素中第一个无效元素的错误
+func cardElementError(elements ...interface{}) error {
+	for _, elem := range elements {
+		err := walkCardElement(elem, func(v interface{}) error {
+			if invalid, ok := v.(invalidCardElement); ok {
+				return invalid.err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	// 分栏中支持的元素, 交互组件仅在表单容器中的分栏内可用, 由 Message.Validate 校验
+	_cardColumnAllowed = map[string]bool{
+		"div": true, "markdown": true, "hr": true, "img": true, "note": true, "column_set": true, "action": true,
+		"chart": true, "person": true, "person_list": true, "avatar": true,
+		"button": true, "input": true, "checker": true,
+		"select_static": true, "multi_select_static": true, "select_person": true,
+		"date_picker": true, "picker_time": true, "picker_datetime": true,
+	}
+	// 折叠面板中支持的元素, 折叠面板不能嵌套
+	_cardCollapsiblePanelAllowed = map[string]bool{
+		"div": true, "markdown": true, "hr": true, "img": true, "note": true, "column_set": true, "action": true,
+		"table": true, "chart": true, "person": true, "person_list": true, "avatar": true,
+	}
+)
+
+// buildCardContainerElements 构造容器的子元素, 不支持的元素返回 invalidCardElement
+func buildCardContainerElements(container string, allowed map[string]bool, elements []CardElement) ([]interface{}, *invalidCardElement) {
+	es := make([]interface{}, 0, len(elements))
+	for _, fn := range elements {
+		if fn == nil {
+			continue
+		}
+		elem := fn(false)
+		if invalid, ok := elem.(invalidCardElement); ok {
+			return nil, &invalid
+		}
+		var tag string
+		if e, ok := elem.(cardElement); ok {
+			tag = e.cardTag()
+		}
+		if !allowed[tag] {
+			return nil, &invalidCardElement{err: fmt.Errorf("card %s: %w: %q", container, ErrCardElementNotAllowed, tag)}
+		}
+		es = append(es, elem)
+	}
+	return es, nil
+}
+
+// CardVerticalAlign 垂直对齐方式
+type CardVerticalAlign string
+
+const (
+	CardVerticalAlignTop    CardVerticalAlign = "top"
+	CardVerticalAlignCenter CardVerticalAlign = "center"
+	CardVerticalAlignBottom CardVerticalAlign = "bottom"
+)
+
+// CardColumnSetFlexMode 移动端和 PC 端的窄屏幕下, 各列的自适应方式
+//  none: 不做布局上的自适应, 在窄屏幕下按比例压缩列宽度
+//  stretch: 列布局变为行布局, 且每列（行）宽度强制拉伸为 100%, 所有列自适应为上下堆叠排布
+//  flow: 列流式排布（自动换行）, 当一行展示不下一列时, 自动换至下一行展示
+//  bisect: 两列等分布局
+//  trisect: 三列等分布局
+type CardColumnSetFlexMode string
+
+const (
+	CardFlexModeNone    CardColumnSetFlexMode = "none"
+	CardFlexModeStretch CardColumnSetFlexMode = "stretch"
+	CardFlexModeFlow    CardColumnSetFlexMode = "flow"
+	CardFlexModeBisect  CardColumnSetFlexMode = "bisect"
+	CardFlexModeTrisect CardColumnSetFlexMode = "trisect"
+)
+
+type CardColumnSetOption func(elem *cardColumnSet)
+
+// WithCardColumnSetFlexMode 窄屏幕下各列的自适应方式, 默认 none
+func WithCardColumnSetFlexMode(mode CardColumnSetFlexMode) CardColumnSetOption {
+	return func(elem *cardColumnSet) {
+		elem.FlexMode = string(mode)
+	}
+}
+
+// WithCardColumnSetBackgroundStyle 多列布局的背景色样式
+//  default: 默认的白底样式, grey: 灰底样式
+func WithCardColumnSetBackgroundStyle(style string) CardColumnSetOption {
+	return func(elem *cardColumnSet) {
+		elem.BackgroundStyle = style
+	}
+}
+
+// WithCardColumnSetHorizontalSpacing 各列之间的水平分栏间距
+//  default: 默认间距, small: 窄间距
+func WithCardColumnSetHorizontalSpacing(spacing string) CardColumnSetOption {
+	return func(elem *cardColumnSet) {
+		elem.HorizontalSpacing = spacing
+	}
+}
+
+type CardColumn func() interface{}
+
+// WithCardElementColumnSet 多列布局, 可嵌套在分栏及折叠面板中
+//  Doc: https://open.feishu.cn/document/common-capabilities/message-card/message-cards-content/column-set
+func WithCardElementColumnSet(columns []CardColumn, opts ...CardColumnSetOption) CardElement {
+	return func(bool) interface{} {
+		cs := make([]interface{}, 0, len(columns))
+		for _, fn := range columns {
+			if fn == nil {
+				continue
+			}
+			col := fn()
+			if invalid, ok := col.(invalidCardElement); ok {
+				return invalid
+			}
+			cs = append(cs, col)
+		}
+
+		elem := &cardColumnSet{
+			Tag:      "column_set",
+			FlexMode: string(CardFlexModeNone),
+			Columns:  cs,
+		}
+		for _, fn := range opts {
+			fn(elem)
+		}
+		return elem
+	}
+}
+
+var _cardColumnWidthPx = regexp.MustCompile(`^([0-9]+)px$`)
+
+func validCardColumnWidth(width string) bool {
+	if width == "auto" || width == "weighted" {
+		return true
+	}
+	m := _cardColumnWidthPx.FindStringSubmatch(width)
+	if m == nil {
+		return false
+	}
+	px, _ := strconv.Atoi(m[1])
+	return px >= 16 && px <= 600
+}
+
+type CardColumnOption func(col *cardColumn)
+
+// WithCardColumnWidth 列宽度
+//  auto: 列宽度与列内元素宽度一致
+//  weighted: 列宽度按 WithCardColumnWeight 定义的权重分布
+//  16px~600px: 固定宽度, 如 100px
+func WithCardColumnWidth(width string) CardColumnOption {
+	return func(col *cardColumn) {
+		col.Width = width
+	}
+}
+
+// WithCardColumnWeight 当宽度为 weighted 时, 当前列的宽度占比, 取值 1~5
+func WithCardColumnWeight(weight int) CardColumnOption {
+	return func(col *cardColumn) {
+		col.Weight = weight
+	}
+}
+
+// WithCardColumnVerticalAlign 列内元素的垂直对齐方式, 默认 top
+func WithCardColumnVerticalAlign(align CardVerticalAlign) CardColumnOption {
+	return func(col *cardColumn) {
+		col.VerticalAlign = string(align)
+	}
+}
+
+// WithCardColumn 多列布局中的一列
+//  支持元素:
+//  - WithCardElementPlainText
+//  - WithCardElementMarkdown
+//  - WithCardElementHorizontalRule
+//  - WithCardElementImage
+//  - WithCardElementNote
+//  - WithCardElementActions
+//  - WithCardElementColumnSet
+//  - WithCardElementChart
+//  - WithCardElementPerson, WithCardElementPersonList, WithCardElementAvatar
+//  - WithCardElementInput, WithCardElementChecker, WithCardElementInteractive: 仅在表单容器中
+func WithCardColumn(elements []CardElement, opts ...CardColumnOption) CardColumn {
+	return func() interface{} {
+		es, invalid := buildCardContainerElements("column", _cardColumnAllowed, elements)
+		if invalid != nil {
+			return *invalid
+		}
+
+		col := &cardColumn{
+			Tag:      "column",
+			Width:    "weighted",
+			Weight:   1,
+			Elements: es,
+		}
+		for _, fn := range opts {
+			fn(col)
+		}
+
+		if !validCardColumnWidth(col.Width) {
+			return invalidCardElement{err: fmt.Errorf("card column: invalid width %q", col.Width)}
+		}
+		if col.Weight < 1 || col.Weight > 5 {
+			return invalidCardElement{err: fmt.Errorf("card column: weight %d out of range [1, 5]", col.Weight)}
+		}
+		return col
+	}
+}
+
+type CardCollapsiblePanelOption func(panel *cardCollapsiblePanel)
+
+// WithCardCollapsiblePanelExpanded 是否默认展开, 默认 false
+func WithCardCollapsiblePanelExpanded(b bool) CardCollapsiblePanelOption {
+	return func(panel *cardCollapsiblePanel) {
+		panel.Expanded = b
+	}
+}
+
+// WithCardCollapsiblePanelBackgroundColor 面板的背景色, 默认透明
+func WithCardCollapsiblePanelBackgroundColor(color string) CardCollapsiblePanelOption {
+	return func(panel *cardCollapsiblePanel) {
+		panel.BackgroundColor = color
+	}
+}
+
+// WithCardCollapsiblePanelBorder 面板的边框
+//  color: 边框颜色, 如 grey
+//  cornerRadius: 圆角半径, 如 5px
+func WithCardCollapsiblePanelBorder(color, cornerRadius string) CardCollapsiblePanelOption {
+	return func(panel *cardCollapsiblePanel) {
+		panel.Border = &cardBorder{
+			Color:        color,
+			CornerRadius: cornerRadius,
+		}
+	}
+}
+
+// WithCardElementCollapsiblePanel 折叠面板, 仅支持在卡片根节点使用
+//  title: 面板标题, 支持 Markdown
+//  支持元素:
+//  - WithCardElementPlainText
+//  - WithCardElementMarkdown
+//  - WithCardElementHorizontalRule
+//  - WithCardElementImage
+//  - WithCardElementNote
+//  - WithCardElementActions
+//  - WithCardElementColumnSet
+//  - WithCardElementTable
+//  - WithCardElementChart
+//  - WithCardElementPerson, WithCardElementPersonList, WithCardElementAvatar
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-components/containers/collapsible-panel
+func WithCardElementCollapsiblePanel(title string, elements []CardElement, opts ...CardCollapsiblePanelOption) CardElement {
+	return func(bool) interface{} {
+		es, invalid := buildCardContainerElements("collapsible_panel", _cardCollapsiblePanelAllowed, elements)
+		if invalid != nil {
+			return *invalid
+		}
+
+		panel := &cardCollapsiblePanel{
+			Tag: "collapsible_panel",
+			Header: cardPanelHeader{
+				Title: cardText{
+					Tag:     "markdown",
+					Content: title,
+				},
+			},
+			Elements: es,
+		}
+		for _, fn := range opts {
+			fn(panel)
+		}
+		return panel
+	}
+}
@@ -0,0 +1,99 @@
+package feishu
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWithCardElementColumnSet(t *testing.T) {
+	msg := NewMessageCard(BgColorBlue, nil,
+		WithCard(LangChinese, "看板",
+			WithCardElementColumnSet([]CardColumn{
+				WithCardColumn([]CardElement{
+					WithCardElementMarkdown("**今日**"),
+					WithCardElementColumnSet([]CardColumn{
+						WithCardColumn([]CardElement{WithCardElementPlainText("嵌套")}, WithCardColumnWidth("auto")),
+					}),
+				}, WithCardColumnWeight(2), WithCardColumnVerticalAlign(CardVerticalAlignCenter)),
+				WithCardColumn([]CardElement{WithCardElementHorizontalRule()}, WithCardColumnWidth("100px")),
+			}, WithCardColumnSetFlexMode(CardFlexModeBisect), WithCardColumnSetBackgroundStyle("grey")),
+			WithCardElementCollapsiblePanel("**详情**", []CardElement{
+				WithCardElementPlainText("内容"),
+			}, WithCardCollapsiblePanelExpanded(true), WithCardCollapsiblePanelBorder("grey", "5px")),
+		),
+	)
+	content, err := msg.marshalContent()
+	requireNil(t, err)
+
+	var card struct {
+		I18nElements map[string][]json.RawMessage `json:"i18n_elements"`
+	}
+	requireNil(t, json.Unmarshal([]byte(content), &card))
+	elements := card.I18nElements[string(LangChinese)]
+	if len(elements) != 2 {
+		t.Fatalf("elements: %d", len(elements))
+	}
+
+	wantColumnSet := `{"background_style":"grey","columns":[` +
+		`{"elements":[{"tag":"div","text":{"content":"**今日**","tag":"lark_md"}},{"columns":[{"elements":[{"tag":"div","text":{"content":"嵌套","tag":"plain_text"}}],"tag":"column","weight":1,"width":"auto"}],"flex_mode":"none","tag":"column_set"}],"tag":"column","vertical_align":"center","weight":2,"width":"weighted"},` +
+		`{"elements":[{"tag":"hr"}],"tag":"column","weight":1,"width":"100px"}` +
+		`],"flex_mode":"bisect","tag":"column_set"}`
+	if got := string(elements[0]); got != wantColumnSet {
+		t.Errorf("column_set\n got: %s\nwant: %s", got, wantColumnSet)
+	}
+	wantPanel := `{"border":{"color":"grey","corner_radius":"5px"},"elements":[{"tag":"div","text":{"content":"内容","tag":"plain_text"}}],"expanded":true,"header":{"title":{"content":"**详情**","tag":"markdown"}},"tag":"collapsible_panel"}`
+	if got := string(elements[1]); got != wantPanel {
+		t.Errorf("collapsible_panel\n got: %s\nwant: %s", got, wantPanel)
+	}
+}
+
+func TestWithCardElementColumnSet_invalid(t *testing.T) {
+	panel := WithCardElementCollapsiblePanel("panel", []CardElement{WithCardElementPlainText("内容")})
+	tests := []struct {
+		name    string
+		elem    CardElement
+		notElem bool
+	}{
+		{
+			name: "panel in column",
+			elem: WithCardElementColumnSet([]CardColumn{WithCardColumn([]CardElement{panel})}),
+		},
+		{
+			name: "nested panel",
+			elem: WithCardElementCollapsiblePanel("outer", []CardElement{panel}),
+		},
+		{
+			name: "deeply nested",
+			elem: WithCardElementCollapsiblePanel("outer", []CardElement{
+				WithCardElementColumnSet([]CardColumn{WithCardColumn([]CardElement{panel})}),
+			}),
+		},
+		{
+			name:    "invalid width",
+			elem:    WithCardElementColumnSet([]CardColumn{WithCardColumn(nil, WithCardColumnWidth("700px"))}),
+			notElem: true,
+		},
+		{
+			name:    "invalid weight",
+			elem:    WithCardElementColumnSet([]CardColumn{WithCardColumn(nil, WithCardColumnWeight(6))}),
+			notElem: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题", tt.elem))
+			_, err := msg.marshalContent()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if errors.Is(err, ErrCardElementNotAllowed) == tt.notElem {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if _, err = json.Marshal(tt.elem(false)); err == nil {
+				t.Error("expected json.Marshal error")
+			}
+		})
+	}
+}
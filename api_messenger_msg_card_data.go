@@ -0,0 +1,412 @@
+package feishu
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const (
+	_cardTableMaxColumns   = 50  // 表格最多的列数
+	_cardTableMaxRows      = 100 // 表格最多的行数
+	_cardTableMaxPageSize  = 10  // 表格每页最多展示的行数
+	_cardPersonListMaxSize = 50  // 人员列表最多的人数
+)
+
+// CardTableColumnType 表格列的数据类型
+//  CardTableColumnText, CardTableColumnLarkMD, CardTableColumnMarkdown: string
+//  CardTableColumnNumber: 整数或浮点数
+//  CardTableColumnOptions: []CardTableTag
+//  CardTableColumnPersons: string 或 []string（Open ID）
+//  CardTableColumnDate: time.Time 或毫秒时间戳 int64
+type CardTableColumnType string
+
+const (
+	CardTableColumnText     CardTableColumnType = "text"
+	CardTableColumnLarkMD   CardTableColumnType = "lark_md"
+	CardTableColumnMarkdown CardTableColumnType = "markdown"
+	CardTableColumnNumber   CardTableColumnType = "number"
+	CardTableColumnOptions  CardTableColumnType = "options"
+	CardTableColumnPersons  CardTableColumnType = "persons"
+	CardTableColumnDate     CardTableColumnType = "date"
+)
+
+// CardTableColumn 表格的列定义
+type CardTableColumn struct {
+	Name            string              // 列的唯一标识, 对应行数据的键
+	DisplayName     string              // 列名称, 为空时不展示
+	Type            CardTableColumnType // 列的数据类型
+	Width           string              // 列宽度, auto 或 80px~600px, 为空时为 auto
+	HorizontalAlign string              // 列内数据的对齐方式, left、center、right, 为空时为 left
+	Precision       *int                // CardTableColumnNumber 的小数位数, 为空时不处理
+	Symbol          string              // CardTableColumnNumber 的货币单位, 如 ¥
+	Separator       bool                // CardTableColumnNumber 是否使用千分位分隔符
+	DateFormat      string              // CardTableColumnDate 的日期格式, 如 YYYY/MM/DD, 为空时按 RFC 3339 展示
+}
+
+// CardTableTag 表格 CardTableColumnOptions 列的单个选项
+type CardTableTag struct {
+	Text  string `json:"text"`
+	Color string `json:"color,omitempty"` // 如 blue、red、neutral, 为空时随机
+}
+
+type CardTableOption func(elem *cardTable)
+
+// WithCardTablePageSize 每页最多展示的行数, 取值 1~10, 默认 5
+func WithCardTablePageSize(n int) CardTableOption {
+	return func(elem *cardTable) {
+		elem.PageSize = n
+	}
+}
+
+// WithCardTableRowHeight 行高
+//  low、middle、high 或 32px~124px, 默认 low
+func WithCardTableRowHeight(height string) CardTableOption {
+	return func(elem *cardTable) {
+		elem.RowHeight = height
+	}
+}
+
+// WithCardTableFreezeFirstColumn 是否冻结首列, 默认 false
+func WithCardTableFreezeFirstColumn(b bool) CardTableOption {
+	return func(elem *cardTable) {
+		elem.FreezeFirstColumn = &b
+	}
+}
+
+// WithCardElementTable 表格
+//  rows 中每行的键为 CardTableColumn.Name, 值的类型参见 CardTableColumnType
+//  最多 50 列、100 行
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-components/content-components/table
+func WithCardElementTable(columns []CardTableColumn, rows []map[string]interface{}, opts ...CardTableOption) CardElement {
+	return func(bool) interface{} {
+		elem, err := buildCardTable(columns, rows, opts)
+		if err != nil {
+			return invalidCardElement{err: fmt.Errorf("card table: %w", err)}
+		}
+		return elem
+	}
+}
+
+func buildCardTable(columns []CardTableColumn, rows []map[string]interface{}, opts []CardTableOption) (*cardTable, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns")
+	}
+	if len(columns) > _cardTableMaxColumns {
+		return nil, fmt.Errorf("%d columns exceeds the limit of %d", len(columns), _cardTableMaxColumns)
+	}
+	if len(rows) > _cardTableMaxRows {
+		return nil, fmt.Errorf("%d rows exceeds the limit of %d", len(rows), _cardTableMaxRows)
+	}
+
+	types := make(map[string]CardTableColumnType, len(columns))
+	cs := make([]cardTableCol, 0, len(columns))
+	for _, col := range columns {
+		if col.Name == "" {
+			return nil, fmt.Errorf("column name is empty")
+		}
+		if _, ok := types[col.Name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", col.Name)
+		}
+		switch col.Type {
+		case CardTableColumnText, CardTableColumnLarkMD, CardTableColumnMarkdown, CardTableColumnNumber,
+			CardTableColumnOptions, CardTableColumnPersons, CardTableColumnDate:
+		default:
+			return nil, fmt.Errorf("column %q: unsupported type %q", col.Name, col.Type)
+		}
+		types[col.Name] = col.Type
+		cs = append(cs, buildCardTableColumn(col))
+	}
+
+	rs := make([]map[string]interface{}, 0, len(rows))
+	for i, row := range rows {
+		r := make(map[string]interface{}, len(row))
+		for name, value := range row {
+			typ, ok := types[name]
+			if !ok {
+				return nil, fmt.Errorf("row %d: unknown column %q", i, name)
+			}
+			v, err := cardTableCellValue(typ, value)
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, name, err)
+			}
+			r[name] = v
+		}
+		rs = append(rs, r)
+	}
+
+	elem := &cardTable{
+		Tag:      "table",
+		PageSize: 5,
+		Columns:  cs,
+		Rows:     rs,
+	}
+	for _, fn := range opts {
+		fn(elem)
+	}
+	if elem.PageSize < 1 || elem.PageSize > _cardTableMaxPageSize {
+		return nil, fmt.Errorf("page size %d out of range [1, %d]", elem.PageSize, _cardTableMaxPageSize)
+	}
+	return elem, nil
+}
+
+func buildCardTableColumn(col CardTableColumn) cardTableCol {
+	c := cardTableCol{
+		Name:            col.Name,
+		DataType:        string(col.Type),
+		DisplayName:     col.DisplayName,
+		Width:           col.Width,
+		HorizontalAlign: col.HorizontalAlign,
+	}
+	switch col.Type {
+	case CardTableColumnNumber:
+		if col.Precision != nil || col.Symbol != "" || col.Separator {
+			c.Format = &cardTableNumberFmt{
+				Precision: col.Precision,
+				Symbol:    col.Symbol,
+				Separator: col.Separator,
+			}
+		}
+	case CardTableColumnDate:
+		c.DateFormat = col.DateFormat
+	}
+	return c
+}
+
+// cardTableCellValue 校验并转换单元格的值
+func cardTableCellValue(typ CardTableColumnType, value interface{}) (interface{}, error) {
+	switch typ {
+	case CardTableColumnText, CardTableColumnLarkMD, CardTableColumnMarkdown:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case CardTableColumnNumber:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return value, nil
+		}
+	case CardTableColumnOptions:
+		switch v := value.(type) {
+		case []CardTableTag:
+			return v, nil
+		case CardTableTag:
+			return []CardTableTag{v}, nil
+		}
+	case CardTableColumnPersons:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []string:
+			return v, nil
+		}
+	case CardTableColumnDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UnixNano() / int64(time.Millisecond), nil
+		case int64:
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%T is not a valid %s value", value, typ)
+}
+
+// CardChartType 图表类型
+type CardChartType string
+
+const (
+	CardChartLine CardChartType = "line"
+	CardChartBar  CardChartType = "bar"
+	CardChartArea CardChartType = "area"
+	CardChartPie  CardChartType = "pie"
+)
+
+// CardChartSeries 图表的一组数据, Values 与 categories 一一对应
+type CardChartSeries struct {
+	Name   string
+	Values []float64
+}
+
+type CardChartOption func(elem *cardChart)
+
+// WithCardChartAspectRatio 图表的宽高比
+//  1:1、2:1、4:3、16:9, 默认 16:9
+func WithCardChartAspectRatio(ratio string) CardChartOption {
+	return func(elem *cardChart) {
+		elem.AspectRatio = ratio
+	}
+}
+
+// WithCardChartPreview 是否支持独立窗口查看, 默认 true
+func WithCardChartPreview(b bool) CardChartOption {
+	return func(elem *cardChart) {
+		elem.Preview = &b
+	}
+}
+
+// WithCardElementChart 图表, 由 categories 及 series 生成 VChart 图表定义
+//  折线图、柱状图、面积图支持多组数据, 饼图仅支持一组数据
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-components/content-components/chart
+func WithCardElementChart(chartType CardChartType, title string, categories []string, series []CardChartSeries, opts ...CardChartOption) CardElement {
+	return func(bool) interface{} {
+		spec, err := buildCardChartSpec(chartType, title, categories, series)
+		if err != nil {
+			return invalidCardElement{err: fmt.Errorf("card chart: %w", err)}
+		}
+		return buildCardChart(spec, opts)
+	}
+}
+
+// WithCardElementChartSpec 图表, 直接使用 VChart 图表定义
+//  VChart: https://www.visactor.io/vchart
+func WithCardElementChartSpec(spec interface{}, opts ...CardChartOption) CardElement {
+	return func(bool) interface{} {
+		return buildCardChart(spec, opts)
+	}
+}
+
+func buildCardChart(spec interface{}, opts []CardChartOption) *cardChart {
+	elem := &cardChart{
+		Tag:       "chart",
+		ChartSpec: spec,
+	}
+	for _, fn := range opts {
+		fn(elem)
+	}
+	return elem
+}
+
+func buildCardChartSpec(chartType CardChartType, title string, categories []string, series []CardChartSeries) (*cardChartSpec, error) {
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no series")
+	}
+	for _, s := range series {
+		if len(s.Values) != len(categories) {
+			return nil, fmt.Errorf("series %q has %d values, want %d", s.Name, len(s.Values), len(categories))
+		}
+	}
+
+	spec := &cardChartSpec{
+		Type: string(chartType),
+	}
+	if title != "" {
+		spec.Title = &cardChartTitle{Text: title}
+	}
+
+	switch chartType {
+	case CardChartLine, CardChartBar, CardChartArea:
+		values := make([]cardChartPoint, 0, len(categories)*len(series))
+		for _, s := range series {
+			for i, c := range categories {
+				values = append(values, cardChartPoint{X: c, Y: s.Values[i], Series: s.Name})
+			}
+		}
+		spec.Data = []cardChartData{{Values: values}}
+		spec.XField = "x"
+		spec.YField = "y"
+		spec.SeriesField = "series"
+		if chartType == CardChartBar && len(series) > 1 {
+			// 多组数据的柱状图分组展示
+			spec.XField = []string{"x", "series"}
+		}
+		if len(series) > 1 {
+			spec.Legends = &cardChartLegends{Visible: true}
+		}
+	case CardChartPie:
+		if len(series) != 1 {
+			return nil, fmt.Errorf("pie chart supports exactly one series, got %d", len(series))
+		}
+		values := make([]cardChartSlice, 0, len(categories))
+		for i, c := range categories {
+			values = append(values, cardChartSlice{Category: c, Value: series[0].Values[i]})
+		}
+		spec.Data = []cardChartData{{Values: values}}
+		spec.CategoryField = "category"
+		spec.ValueField = "value"
+		spec.Legends = &cardChartLegends{Visible: true}
+	default:
+		return nil, fmt.Errorf("unsupported chart type %q", chartType)
+	}
+	return spec, nil
+}
+
+// CardPersonSize 人员头像尺寸
+type CardPersonSize string
+
+const (
+	CardPersonSizeExtraSmall CardPersonSize = "extra_small"
+	CardPersonSizeSmall      CardPersonSize = "small"
+	CardPersonSizeMedium     CardPersonSize = "medium"
+	CardPersonSizeLarge      CardPersonSize = "large"
+)
+
+type CardPersonOption func(elem *cardPerson)
+
+// WithCardPersonSize 头像尺寸, 默认 medium
+func WithCardPersonSize(size CardPersonSize) CardPersonOption {
+	return func(elem *cardPerson) {
+		elem.Size = string(size)
+	}
+}
+
+// WithCardPersonShowName 是否展示用户名, 默认 true, 对 WithCardElementAvatar 无效
+func WithCardPersonShowName(b bool) CardPersonOption {
+	return func(elem *cardPerson) {
+		elem.ShowName = &b
+	}
+}
+
+// WithCardPersonShowAvatar 是否展示头像, 默认 true, 对 WithCardElementAvatar 无效
+func WithCardPersonShowAvatar(b bool) CardPersonOption {
+	return func(elem *cardPerson) {
+		elem.ShowAvatar = &b
+	}
+}
+
+// WithCardElementPerson 人员, 展示用户的头像及用户名
+func WithCardElementPerson(openID string, opts ...CardPersonOption) CardElement {
+	return func(bool) interface{} {
+		return buildCardPerson(&cardPerson{
+			Tag:    "person",
+			UserID: openID,
+		}, opts)
+	}
+}
+
+// WithCardElementPersonList 人员列表, 最多 50 人
+func WithCardElementPersonList(openIDs []string, opts ...CardPersonOption) CardElement {
+	return func(bool) interface{} {
+		if len(openIDs) == 0 {
+			return invalidCardElement{err: fmt.Errorf("card person_list: no persons")}
+		}
+		if len(openIDs) > _cardPersonListMaxSize {
+			return invalidCardElement{err: fmt.Errorf("card person_list: %d persons exceeds the limit of %d", len(openIDs), _cardPersonListMaxSize)}
+		}
+		persons := make([]cardPersonID, 0, len(openIDs))
+		for _, id := range openIDs {
+			persons = append(persons, cardPersonID{ID: id})
+		}
+		return buildCardPerson(&cardPerson{
+			Tag:     "person_list",
+			Persons: persons,
+		}, opts)
+	}
+}
+
+// WithCardElementAvatar 用户头像
+func WithCardElementAvatar(openID string, opts ...CardPersonOption) CardElement {
+	return func(bool) interface{} {
+		return buildCardPerson(&cardPerson{
+			Tag:    "avatar",
+			UserID: openID,
+		}, opts)
+	}
+}
+
+func buildCardPerson(elem *cardPerson, opts []CardPersonOption) *cardPerson {
+	for _, fn := range opts {
+		fn(elem)
+	}
+	return elem
+}
@@ -0,0 +1,130 @@
+package feishu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithCardElementTable(t *testing.T) {
+	precision := 2
+	table := WithCardElementTable([]CardTableColumn{
+		{Name: "name", DisplayName: "服务", Type: CardTableColumnText, Width: "120px"},
+		{Name: "qps", DisplayName: "QPS", Type: CardTableColumnNumber, Precision: &precision, Separator: true, HorizontalAlign: "right"},
+		{Name: "status", Type: CardTableColumnOptions},
+		{Name: "owner", Type: CardTableColumnPersons},
+		{Name: "at", Type: CardTableColumnDate, DateFormat: "YYYY/MM/DD"},
+	}, []map[string]interface{}{
+		{
+			"name":   "api",
+			"qps":    1234.5,
+			"status": CardTableTag{Text: "正常", Color: "green"},
+			"owner":  []string{"ou_1", "ou_2"},
+			"at":     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{"name": "worker", "qps": 3},
+	}, WithCardTablePageSize(10), WithCardTableFreezeFirstColumn(true))
+
+	bs, err := json.Marshal(table(false))
+	requireNil(t, err)
+	want := `{"columns":[` +
+		`{"data_type":"text","display_name":"服务","name":"name","width":"120px"},` +
+		`{"data_type":"number","display_name":"QPS","format":{"precision":2,"separator":true},"horizontal_align":"right","name":"qps"},` +
+		`{"data_type":"options","name":"status"},` +
+		`{"data_type":"persons","name":"owner"},` +
+		`{"data_type":"date","date_format":"YYYY/MM/DD","name":"at"}` +
+		`],"freeze_first_column":true,"page_size":10,"rows":[` +
+		`{"at":1704153600000,"name":"api","owner":["ou_1","ou_2"],"qps":1234.5,"status":[{"text":"正常","color":"green"}]},` +
+		`{"name":"worker","qps":3}` +
+		`],"tag":"table"}`
+	if string(bs) != want {
+		t.Errorf("table\n got: %s\nwant: %s", bs, want)
+	}
+
+	columns := []CardTableColumn{{Name: "n", Type: CardTableColumnNumber}}
+	tooManyRows := make([]map[string]interface{}, _cardTableMaxRows+1)
+	tooManyColumns := make([]CardTableColumn, _cardTableMaxColumns+1)
+	for i := range tooManyColumns {
+		tooManyColumns[i] = CardTableColumn{Name: strings.Repeat("c", i+1), Type: CardTableColumnText}
+	}
+	invalid := map[string]CardElement{
+		"exceeds the limit of 100": WithCardElementTable(columns, tooManyRows),
+		"exceeds the limit of 50":  WithCardElementTable(tooManyColumns, nil),
+		"unsupported type":         WithCardElementTable([]CardTableColumn{{Name: "n", Type: "image"}}, nil),
+		"not a valid number":       WithCardElementTable(columns, []map[string]interface{}{{"n": "1"}}),
+		"unknown column":           WithCardElementTable(columns, []map[string]interface{}{{"m": 1}}),
+		"page size 11":             WithCardElementTable(columns, nil, WithCardTablePageSize(11)),
+	}
+	for wantErr, elem := range invalid {
+		_, err := NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题", elem)).marshalContent()
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("error = %v, want %q", err, wantErr)
+		}
+	}
+}
+
+func TestWithCardElementChart(t *testing.T) {
+	categories := []string{"周一", "周二"}
+	tests := []struct {
+		name string
+		elem CardElement
+		want string
+	}{
+		{
+			name: "line",
+			elem: WithCardElementChart(CardChartLine, "趋势", categories, []CardChartSeries{{Name: "PV", Values: []float64{1, 2}}}),
+			want: `{"chart_spec":{"data":[{"values":[{"series":"PV","x":"周一","y":1},{"series":"PV","x":"周二","y":2}]}],"seriesField":"series","title":{"text":"趋势"},"type":"line","xField":"x","yField":"y"},"tag":"chart"}`,
+		},
+		{
+			name: "grouped bar",
+			elem: WithCardElementChart(CardChartBar, "", categories, []CardChartSeries{
+				{Name: "A", Values: []float64{1, 2}},
+				{Name: "B", Values: []float64{3, 4}},
+			}, WithCardChartAspectRatio("4:3")),
+			want: `{"aspect_ratio":"4:3","chart_spec":{"data":[{"values":[{"series":"A","x":"周一","y":1},{"series":"A","x":"周二","y":2},{"series":"B","x":"周一","y":3},{"series":"B","x":"周二","y":4}]}],"legends":{"visible":true},"seriesField":"series","type":"bar","xField":["x","series"],"yField":"y"},"tag":"chart"}`,
+		},
+		{
+			name: "pie",
+			elem: WithCardElementChart(CardChartPie, "", categories, []CardChartSeries{{Values: []float64{30, 70}}}),
+			want: `{"chart_spec":{"categoryField":"category","data":[{"values":[{"category":"周一","value":30},{"category":"周二","value":70}]}],"legends":{"visible":true},"type":"pie","valueField":"value"},"tag":"chart"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs, err := json.Marshal(tt.elem(false))
+			requireNil(t, err)
+			if string(bs) != tt.want {
+				t.Errorf("chart\n got: %s\nwant: %s", bs, tt.want)
+			}
+		})
+	}
+
+	invalid := []CardElement{
+		WithCardElementChart(CardChartLine, "", categories, []CardChartSeries{{Values: []float64{1}}}),
+		WithCardElementChart(CardChartPie, "", categories, []CardChartSeries{{Values: []float64{1, 2}}, {Values: []float64{1, 2}}}),
+		WithCardElementChart("radar", "", categories, []CardChartSeries{{Values: []float64{1, 2}}}),
+	}
+	for _, elem := range invalid {
+		if _, err := json.Marshal(elem(false)); err == nil {
+			t.Error("expected error")
+		}
+	}
+}
+
+func TestWithCardElementPersonList(t *testing.T) {
+	bs, err := json.Marshal([]interface{}{
+		WithCardElementPerson("ou_1", WithCardPersonSize(CardPersonSizeSmall))(false),
+		WithCardElementPersonList([]string{"ou_1", "ou_2"}, WithCardPersonShowName(false))(false),
+		WithCardElementAvatar("ou_1")(false),
+	})
+	requireNil(t, err)
+	want := `[{"size":"small","tag":"person","user_id":"ou_1"},{"persons":[{"id":"ou_1"},{"id":"ou_2"}],"show_name":false,"tag":"person_list"},{"tag":"avatar","user_id":"ou_1"}]`
+	if string(bs) != want {
+		t.Errorf("persons\n got: %s\nwant: %s", bs, want)
+	}
+
+	if _, err = json.Marshal(WithCardElementPersonList(make([]string, _cardPersonListMaxSize+1))(false)); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,305 @@
+package feishu
+
+import (
+	"fmt"
+)
+
+// WithCardElementActionValue 交互组件被操作时回调的 value
+//  仅支持 map[string]T 或 struct, 回调时原样返回
+func WithCardElementActionValue(value interface{}) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "value", value
+	}
+}
+
+// WithCardElementActionConfirm 交互组件被操作时的二次确认弹框
+func WithCardElementActionConfirm(title, text string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "confirm", &cardConfirm{
+			Title: cardText{
+				Tag:     "plain_text",
+				Content: title,
+			},
+			Text: cardText{
+				Tag:     "plain_text",
+				Content: text,
+			},
+		}
+	}
+}
+
+// WithCardElementActionName 交互组件的唯一标识, 在表单容器中必填
+//  提交表单时以 name 作为 form_value 的键
+func WithCardElementActionName(name string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "name", name
+	}
+}
+
+// WithCardElementActionPlaceholder 交互组件未选择或未输入时的占位文本
+func WithCardElementActionPlaceholder(text string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "placeholder", &cardText{
+			Tag:     "plain_text",
+			Content: text,
+		}
+	}
+}
+
+// WithCardElementActionRequired 在表单容器中是否必填, 默认 false
+func WithCardElementActionRequired(b bool) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "required", b
+	}
+}
+
+// WithCardElementActionInitialOption 下拉选择的默认选项, 对应 CardOption.Value
+func WithCardElementActionInitialOption(value string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "initial_option", value
+	}
+}
+
+// WithCardElementActionSelectedValues 多选下拉选择的默认选项, 对应 CardOption.Value
+func WithCardElementActionSelectedValues(values ...string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "selected_values", values
+	}
+}
+
+// WithCardElementActionDefaultValue 输入框的默认内容
+func WithCardElementActionDefaultValue(text string) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "default_value", text
+	}
+}
+
+// WithCardElementActionMaxLength 输入框可输入的最大字符数, 取值 1~1000
+func WithCardElementActionMaxLength(n int) CardElementActionOption {
+	return func() (key string, v interface{}) {
+		return "max_length", n
+	}
+}
+
+// CardOption 下拉选择及折叠按钮组的选项
+//  URL 仅在折叠按钮组中生效, 点击后跳转
+type CardOption struct {
+	Text  string
+	Value string
+	URL   string
+}
+
+func buildCardOptions(options []CardOption) []cardOption {
+	es := make([]cardOption, 0, len(options))
+	for _, o := range options {
+		es = append(es, cardOption{
+			Text: &cardText{
+				Tag:     "plain_text",
+				Content: o.Text,
+			},
+			Value: o.Value,
+			URL:   o.URL,
+		})
+	}
+	return es
+}
+
+func buildCardInteractive(elem *cardInteractive, opts []CardElementActionOption) *cardInteractive {
+	for _, fn := range opts {
+		if fn == nil {
+			continue
+		}
+		elem.set(fn())
+	}
+	return elem
+}
+
+// WithCardElementSelectStatic 单选下拉选择
+func WithCardElementSelectStatic(options []CardOption, opts ...CardElementActionOption) CardElementAction {
+	return func() interface{} {
+		return buildCardInteractive(&cardInteractive{
+			Tag:     "select_static",
+			Options: buildCardOptions(options),
+		}, opts)
+	}
+}
+
+// WithCardElementMultiSelectStatic 多选下拉选择, 仅支持在表单容器中使用
+func WithCardElementMultiSelectStatic(options []CardOption, opts ...CardElementActionOption) CardElementAction {
+	return func() interface{} {
+		return buildCardInteractive(&cardInteractive{
+			Tag:     "multi_select_static",
+			Options: buildCardOptions(options),
+		}, opts)
+	}
+}
+
+// WithCardElementSelectPerson 人员选择
+//  openIDs 为可选人员, 为空时可选择卡片所在会话的成员
+func WithCardElementSelectPerson(openIDs []string, opts ...CardElementActionOption) CardElementAction {
+	return func() interface{} {
+		elem := &cardInteractive{Tag: "select_person"}
+		if len(openIDs) != 0 {
+			elem.Options = make([]cardOption, 0, len(openIDs))
+			for _, id := range openIDs {
+				elem.Options = append(elem.Options, cardOption{Value: id})
+			}
+		}
+		return buildCardInteractive(elem, opts)
+	}
+}
+
+// WithCardElementOverflow 折叠按钮组
+func WithCardElementOverflow(options []CardOption, opts ...CardElementActionOption) CardElementAction {
+	return func() interface{} {
+		return buildCardInteractive(&cardInteractive{
+			Tag:     "overflow",
+			Options: buildCardOptions(options),
+		}, opts)
+	}
+}
+
+// WithCardElementDatePicker 日期选择器
+//  initial: 默认日期, 格式 2006-01-02, 为空时不指定
+func WithCardElementDatePicker(initial string, opts ...CardElementActionOption) CardElementAction {
+	return newCardElementPicker("date_picker", initial, opts)
+}
+
+// WithCardElementTimePicker 时间选择器
+//  initial: 默认时间, 格式 15:04, 为空时不指定
+func WithCardElementTimePicker(initial string, opts ...CardElementActionOption) CardElementAction {
+	return newCardElementPicker("picker_time", initial, opts)
+}
+
+// WithCardElementDatetimePicker 日期时间选择器
+//  initial: 默认日期时间, 格式 2006-01-02 15:04, 为空时不指定
+func WithCardElementDatetimePicker(initial string, opts ...CardElementActionOption) CardElementAction {
+	return newCardElementPicker("picker_datetime", initial, opts)
+}
+
+func newCardElementPicker(tag, initial string, opts []CardElementActionOption) CardElementAction {
+	return func() interface{} {
+		elem := &cardInteractive{Tag: tag}
+		switch tag {
+		case "date_picker":
+			elem.InitialDate = initial
+		case "picker_time":
+			elem.InitialTime = initial
+		case "picker_datetime":
+			elem.InitialDatetime = initial
+		}
+		return buildCardInteractive(elem, opts)
+	}
+}
+
+// WithCardElementFormSubmitButton 表单容器的提交按钮
+//  点击后回调表单中所有交互组件的值
+func WithCardElementFormSubmitButton(elem CardElement, name string, opts ...CardElementActionOption) CardElementAction {
+	return newCardElementFormButton("form_submit", elem, name, opts)
+}
+
+// WithCardElementFormResetButton 表单容器的重置按钮
+func WithCardElementFormResetButton(elem CardElement, name string, opts ...CardElementActionOption) CardElementAction {
+	return newCardElementFormButton("form_reset", elem, name, opts)
+}
+
+func newCardElementFormButton(actionType string, elem CardElement, name string, opts []CardElementActionOption) CardElementAction {
+	opts = append([]CardElementActionOption{
+		func() (key string, v interface{}) {
+			return "action_type", actionType
+		},
+		WithCardElementActionName(name),
+	}, opts...)
+	return WithCardElementAction(elem, "", opts...)
+}
+
+// WithCardElementInteractive 将交互组件作为独立的卡片元素, 用于表单容器
+func WithCardElementInteractive(act CardElementAction) CardElement {
+	return func(bool) interface{} {
+		return act()
+	}
+}
+
+// WithCardElementInput 输入框, 仅支持在表单容器中使用
+func WithCardElementInput(name string, opts ...CardElementActionOption) CardElement {
+	return func(bool) interface{} {
+		return buildCardInteractive(&cardInteractive{
+			Tag:  "input",
+			Name: name,
+		}, opts)
+	}
+}
+
+// WithCardElementChecker 勾选器
+//  支持文本元素:
+//  - WithCardElementPlainText
+//  - WithCardElementMarkdown
+func WithCardElementChecker(text CardElement, checked bool, opts ...CardElementActionOption) CardElement {
+	return func(bool) interface{} {
+		elem := &cardInteractive{
+			Tag:     "checker",
+			Checked: &checked,
+		}
+		// text 为 nil 时由 Message.Validate 报告缺少文本
+		if text != nil {
+			elem.Text = text(true)
+		}
+		return buildCardInteractive(elem, opts)
+	}
+}
+
+var (
+	// 表单容器中支持的元素, 表单容器不能嵌套
+	_cardFormAllowed = map[string]bool{
+		"div": true, "markdown": true, "hr": true, "img": true, "note": true, "column_set": true,
+		"button": true, "input": true, "checker": true,
+		"select_static": true, "multi_select_static": true, "select_person": true,
+		"date_picker": true, "picker_time": true, "picker_datetime": true,
+	}
+	// 表单容器中需要 name 的元素
+	_cardFormNamed = map[string]bool{
+		"button": true, "input": true, "checker": true,
+		"select_static": true, "multi_select_static": true, "select_person": true,
+		"date_picker": true, "picker_time": true, "picker_datetime": true,
+	}
+)
+
+// WithCardElementForm 表单容器, 点击提交按钮后一次性回调所有交互组件的值
+//  交互组件使用 WithCardElementInteractive 转换为卡片元素, 且必须指定 WithCardElementActionName
+//  支持元素:
+//  - WithCardElementPlainText
+//  - WithCardElementMarkdown
+//  - WithCardElementHorizontalRule
+//  - WithCardElementImage
+//  - WithCardElementNote
+//  - WithCardElementColumnSet: 分栏中可包含交互组件, 如并排的提交、重置按钮
+//  - WithCardElementInput
+//  - WithCardElementChecker
+//  - WithCardElementInteractive
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-components/containers/form-container
+func WithCardElementForm(name string, elements []CardElement) CardElement {
+	return func(bool) interface{} {
+		es, invalid := buildCardContainerElements("form", _cardFormAllowed, elements)
+		if invalid != nil {
+			return *invalid
+		}
+		// 交互组件可在多列布局中
+		for _, elem := range es {
+			err := walkCardElement(elem, func(v interface{}) error {
+				if e, ok := v.(*cardInteractive); ok && _cardFormNamed[e.Tag] && e.Name == "" {
+					return fmt.Errorf("card form: %q requires a name", e.Tag)
+				}
+				return nil
+			})
+			if err != nil {
+				return invalidCardElement{err: err}
+			}
+		}
+
+		return &cardForm{
+			Tag:      "form",
+			Name:     name,
+			Elements: es,
+		}
+	}
+}
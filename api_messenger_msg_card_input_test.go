@@ -0,0 +1,102 @@
+package feishu
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithCardElementForm(t *testing.T) {
+	value := map[string]string{"ticket": "T-1"}
+	form := WithCardElementForm("triage", []CardElement{
+		WithCardElementInput("reason",
+			WithCardElementActionPlaceholder("原因"),
+			WithCardElementActionRequired(true),
+			WithCardElementActionMaxLength(100),
+		),
+		WithCardElementInteractive(WithCardElementSelectStatic([]CardOption{
+			{Text: "高", Value: "p0"},
+			{Text: "低", Value: "p2"},
+		}, WithCardElementActionName("priority"), WithCardElementActionInitialOption("p2"))),
+		WithCardElementInteractive(WithCardElementMultiSelectStatic([]CardOption{{Text: "后端", Value: "be"}},
+			WithCardElementActionName("teams"), WithCardElementActionSelectedValues("be"))),
+		WithCardElementInteractive(WithCardElementSelectPerson(nil, WithCardElementActionName("owner"))),
+		WithCardElementInteractive(WithCardElementDatetimePicker("2024-01-02 15:04", WithCardElementActionName("due"))),
+		WithCardElementChecker(WithCardElementPlainText("紧急"), false, WithCardElementActionName("urgent")),
+		WithCardElementInteractive(WithCardElementFormSubmitButton(WithCardElementPlainText("提交"), "submit",
+			WithCardElementActionButton(ButtonPrimary),
+			WithCardElementActionValue(value),
+			WithCardElementActionConfirm("确认", "提交后不可修改"),
+		)),
+		WithCardElementInteractive(WithCardElementFormResetButton(WithCardElementPlainText("重置"), "reset")),
+	})
+
+	bs, err := json.Marshal(form(false))
+	requireNil(t, err)
+	want := `{"elements":[` +
+		`{"max_length":100,"name":"reason","placeholder":{"content":"原因","tag":"plain_text"},"required":true,"tag":"input"},` +
+		`{"initial_option":"p2","name":"priority","options":[{"text":{"content":"高","tag":"plain_text"},"value":"p0"},{"text":{"content":"低","tag":"plain_text"},"value":"p2"}],"tag":"select_static"},` +
+		`{"name":"teams","options":[{"text":{"content":"后端","tag":"plain_text"},"value":"be"}],"selected_values":["be"],"tag":"multi_select_static"},` +
+		`{"name":"owner","tag":"select_person"},` +
+		`{"initial_datetime":"2024-01-02 15:04","name":"due","tag":"picker_datetime"},` +
+		`{"checked":false,"name":"urgent","tag":"checker","text":{"content":"紧急","tag":"plain_text"}},` +
+		`{"action_type":"form_submit","confirm":{"text":{"content":"提交后不可修改","tag":"plain_text"},"title":{"content":"确认","tag":"plain_text"}},"name":"submit","tag":"button","text":{"content":"提交","tag":"plain_text"},"type":"primary","value":{"ticket":"T-1"}},` +
+		`{"action_type":"form_reset","name":"reset","tag":"button","text":{"content":"重置","tag":"plain_text"}}` +
+		`],"name":"triage","tag":"form"}`
+	if string(bs) != want {
+		t.Errorf("form\n got: %s\nwant: %s", bs, want)
+	}
+
+	columns := WithCardElementForm("layout", []CardElement{
+		WithCardElementColumnSet([]CardColumn{
+			WithCardColumn([]CardElement{WithCardElementInteractive(WithCardElementFormSubmitButton(WithCardElementPlainText("提交"), "submit"))}),
+			WithCardColumn([]CardElement{WithCardElementInteractive(WithCardElementFormResetButton(WithCardElementPlainText("重置"), "reset"))}),
+		}),
+	})
+	bs, err = json.Marshal(columns(false))
+	requireNil(t, err)
+	want = `{"elements":[{"columns":[` +
+		`{"elements":[{"action_type":"form_submit","name":"submit","tag":"button","text":{"content":"提交","tag":"plain_text"}}],"tag":"column","weight":1,"width":"weighted"},` +
+		`{"elements":[{"action_type":"form_reset","name":"reset","tag":"button","text":{"content":"重置","tag":"plain_text"}}],"tag":"column","weight":1,"width":"weighted"}` +
+		`],"flex_mode":"none","tag":"column_set"}],"name":"layout","tag":"form"}`
+	if string(bs) != want {
+		t.Errorf("form with columns\n got: %s\nwant: %s", bs, want)
+	}
+
+	invalid := []CardElement{
+		WithCardElementForm("missing name", []CardElement{
+			WithCardElementInteractive(WithCardElementDatePicker("")),
+		}),
+		WithCardElementForm("missing name in column", []CardElement{
+			WithCardElementColumnSet([]CardColumn{WithCardColumn([]CardElement{WithCardElementInput("")})}),
+		}),
+		WithCardElementForm("nested", []CardElement{
+			WithCardElementForm("inner", nil),
+		}),
+	}
+	for _, elem := range invalid {
+		msg := NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题", elem))
+		if _, err := msg.marshalContent(); err == nil {
+			t.Error("expected error")
+		}
+	}
+}
+
+func TestWithCardElementActions_interactive(t *testing.T) {
+	actions := WithCardElementActions(
+		WithCardElementAction(WithCardElementPlainText("同意"), "", WithCardElementActionValue(map[string]string{"op": "approve"})),
+		WithCardElementOverflow([]CardOption{{Text: "文档", Value: "doc", URL: "https://open.feishu.cn"}}),
+		WithCardElementDatePicker("2024-01-02", WithCardElementActionPlaceholder("日期")),
+		WithCardElementTimePicker(""),
+	)
+	bs, err := json.Marshal(actions(false))
+	requireNil(t, err)
+	want := `{"actions":[` +
+		`{"tag":"button","text":{"content":"同意","tag":"plain_text"},"value":{"op":"approve"}},` +
+		`{"options":[{"text":{"content":"文档","tag":"plain_text"},"url":"https://open.feishu.cn","value":"doc"}],"tag":"overflow"},` +
+		`{"initial_date":"2024-01-02","placeholder":{"content":"日期","tag":"plain_text"},"tag":"date_picker"},` +
+		`{"tag":"picker_time"}` +
+		`],"tag":"action"}`
+	if string(bs) != want {
+		t.Errorf("actions\n got: %s\nwant: %s", bs, want)
+	}
+}
@@ -0,0 +1,289 @@
+package feishu
+
+import (
+	"fmt"
+)
+
+const _cardHeaderMaxTextTags = 3 // 标题最多的标签数
+
+type CardHeader func() *cardHeaderV2
+
+type CardHeaderOption func(header *cardHeaderV2)
+
+// WithCardHeader JSON 2.0 卡片的标题
+//  支持属性如下:
+//  背景色: WithCardHeaderTemplate
+//  多语言标题: WithCardHeaderI18nTitle
+//  副标题: WithCardHeaderSubtitle, WithCardHeaderI18nSubtitle
+//  标签: WithCardHeaderTextTag
+//  图标: WithCardHeaderStandardIcon, WithCardHeaderCustomIcon
+func WithCardHeader(title string, opts ...CardHeaderOption) CardHeader {
+	return func() *cardHeaderV2 {
+		header := &cardHeaderV2{
+			Title: cardText{
+				Tag:     "plain_text",
+				Content: title,
+			},
+		}
+		for _, fn := range opts {
+			fn(header)
+		}
+		if len(header.TextTagList) > _cardHeaderMaxTextTags {
+			header.err = fmt.Errorf("card header: %d text tags exceeds the limit of %d", len(header.TextTagList), _cardHeaderMaxTextTags)
+		}
+		return header
+	}
+}
+
+// WithCardHeaderTemplate 标题的背景色
+func WithCardHeaderTemplate(bgColor CardTitleBgColor) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		header.Template = string(bgColor)
+	}
+}
+
+// WithCardHeaderI18nTitle 指定语言环境的标题
+func WithCardHeaderI18nTitle(lang Language, title string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		setCardI18nText(&header.Title, lang, title)
+	}
+}
+
+// WithCardHeaderSubtitle 副标题, 不可单独配置副标题而不配置主标题
+func WithCardHeaderSubtitle(text string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		header.Subtitle = &cardText{
+			Tag:     "plain_text",
+			Content: text,
+		}
+	}
+}
+
+// WithCardHeaderI18nSubtitle 指定语言环境的副标题
+func WithCardHeaderI18nSubtitle(lang Language, text string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		if header.Subtitle == nil {
+			header.Subtitle = &cardText{
+				Tag:     "plain_text",
+				Content: text,
+			}
+		}
+		setCardI18nText(header.Subtitle, lang, text)
+	}
+}
+
+func setCardI18nText(t *cardText, lang Language, text string) {
+	if t.I18nContent == nil {
+		t.I18nContent = make(map[string]string, 3)
+	}
+	t.I18nContent[string(lang)] = text
+}
+
+// WithCardHeaderTextTag 标题后缀标签, 最多 3 个
+//  color: 标签颜色, 如 neutral、blue、green、red, 为空时为 neutral
+func WithCardHeaderTextTag(text, color string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		header.TextTagList = append(header.TextTagList, cardTextTag{
+			Tag: "text_tag",
+			Text: cardText{
+				Tag:     "plain_text",
+				Content: text,
+			},
+			Color: color,
+		})
+	}
+}
+
+// WithCardHeaderStandardIcon 标题前缀图标, 使用图标库中的图标
+//  token: 图标库中图标的 token, color: 图标颜色, 为空时使用默认颜色
+//  图标库: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/enumerations-for-icons
+func WithCardHeaderStandardIcon(token, color string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		header.Icon = &cardIcon{
+			Tag:   "standard_icon",
+			Token: token,
+			Color: color,
+		}
+	}
+}
+
+// WithCardHeaderCustomIcon 标题前缀图标, 使用自定义图片
+func WithCardHeaderCustomIcon(imgKey string) CardHeaderOption {
+	return func(header *cardHeaderV2) {
+		header.Icon = &cardIcon{
+			Tag:    "custom_icon",
+			ImgKey: imgKey,
+		}
+	}
+}
+
+// NewMessageCardV2 使用 JSON 2.0 结构构造卡片消息
+//  header 为 nil 时不展示标题, 多语言标题使用 WithCardHeaderI18nTitle
+//  已有的 WithCardElement* 元素会转换为 JSON 2.0 的结构:
+//  - WithCardElementActions 转换为流式排布的多列布局
+//  - WithCardElementNote 转换为流式排布的多列布局, 文本为辅助信息字号
+//  - 按钮及交互组件的 url, multi_url, value 转换为 behaviors
+//  Doc: https://open.feishu.cn/document/uAjLw4CM/ukzMukzMukzM/feishu-cards/card-json-v2-structure
+func NewMessageCardV2(header CardHeader, cfg CardConfig, elem CardElement, elements ...CardElement) *Message {
+	elements = append([]CardElement{elem}, elements...)
+	es := make([]interface{}, 0, len(elements))
+	for _, fn := range elements {
+		if fn == nil {
+			continue
+		}
+		es = append(es, adaptCardElementV2(fn(false)))
+	}
+
+	sub := &msgCardV2{
+		Schema: "2.0",
+		Body:   msgCardBody{Elements: es},
+	}
+	if header != nil {
+		sub.Header = header()
+	}
+	if cfg != nil {
+		_cfg := cfg()
+		sub.Config = _cfg.mCfg
+		sub.CardLink = _cfg.mCardLink
+	}
+
+	err := cardElementError(es...)
+	if err == nil && sub.Header != nil {
+		err = sub.Header.err
+	}
+	return &Message{
+		msgType: "interactive",
+		content: sub,
+		err:     err,
+	}
+}
+
+// adaptCardElementV2 将 JSON 1.0 的卡片元素转换为 JSON 2.0 的结构
+//  返回新的元素, 不修改原有元素
+func adaptCardElementV2(v interface{}) interface{} {
+	switch e := v.(type) {
+	case *cardAction:
+		return cardFlowColumnSetV2(adaptCardElementsV2(e.Actions))
+	case *cardNote:
+		es := make([]interface{}, 0, len(e.Elements))
+		for _, n := range e.Elements {
+			switch note := n.(type) {
+			case *cardImage:
+				img := *note
+				img.ScaleType, img.Mode, img.Size = "crop_center", "", "16px 16px"
+				es = append(es, &img)
+			case *cardText:
+				text := *note
+				text.TextSize, text.TextColor = "notation", "grey"
+				es = append(es, &cardDiv{
+					Tag:  "div",
+					Text: &text,
+				})
+			default:
+				es = append(es, n)
+			}
+		}
+		return cardFlowColumnSetV2(es)
+	case *cardDiv:
+		if e.Extra == nil {
+			return e
+		}
+		div := *e
+		div.Extra = adaptCardElementV2(e.Extra)
+		return &div
+	case *cardImage:
+		if e.Mode == "" {
+			return e
+		}
+		img := *e
+		img.ScaleType, img.Mode = e.Mode, ""
+		return &img
+	case *cardInteractive:
+		elem := *e
+		switch elem.ActionType {
+		case "form_submit":
+			elem.FormActionType = "submit"
+		case "form_reset":
+			elem.FormActionType = "reset"
+		}
+		elem.ActionType = ""
+		adaptCardBehaviorsV2(&elem)
+		return &elem
+	case *cardColumnSet:
+		cs := *e
+		cs.Columns = adaptCardElementsV2(e.Columns)
+		return &cs
+	case *cardColumn:
+		col := *e
+		col.Elements = adaptCardElementsV2(e.Elements)
+		return &col
+	case *cardCollapsiblePanel:
+		panel := *e
+		panel.Elements = adaptCardElementsV2(e.Elements)
+		return &panel
+	case *cardForm:
+		form := *e
+		form.Elements = adaptCardElementsV2(e.Elements)
+		return &form
+	}
+	return v
+}
+
+func adaptCardElementsV2(elements []interface{}) []interface{} {
+	es := make([]interface{}, 0, len(elements))
+	for _, elem := range elements {
+		es = append(es, adaptCardElementV2(elem))
+	}
+	return es
+}
+
+// adaptCardBehaviorsV2 将 url, multi_url, value 转换为 behaviors
+func adaptCardBehaviorsV2(e *cardInteractive) {
+	behaviors := make([]cardBehavior, 0, 2)
+	if e.URL != "" {
+		behaviors = append(behaviors, cardBehavior{
+			Type:       "open_url",
+			DefaultURL: e.URL,
+		})
+		e.URL = ""
+	}
+	if multi := e.MultiURL; multi != nil {
+		behaviors = append(behaviors, cardBehavior{
+			Type:       "open_url",
+			DefaultURL: multi.URL,
+			AndroidURL: multi.AndroidURL,
+			IOSURL:     multi.IOSURL,
+			PCURL:      multi.PCURL,
+		})
+		e.MultiURL = nil
+	}
+	if e.Value != nil {
+		behaviors = append(behaviors, cardBehavior{
+			Type:  "callback",
+			Value: e.Value,
+		})
+		e.Value = nil
+	}
+	if len(behaviors) != 0 {
+		e.Behaviors = behaviors
+	}
+}
+
+// cardFlowColumnSetV2 将元素放入流式排布的多列布局, 每个元素一列
+func cardFlowColumnSetV2(elements []interface{}) *cardColumnSet {
+	columns := make([]interface{}, 0, len(elements))
+	for _, elem := range elements {
+		columns = append(columns, &cardColumn{
+			Tag:           "column",
+			Width:         "auto",
+			VerticalAlign: "center",
+			Elements:      []interface{}{elem},
+		})
+	}
+	return &cardColumnSet{
+		Tag:               "column_set",
+		FlexMode:          string(CardFlexModeFlow),
+		HorizontalSpacing: "small",
+		Columns:           columns,
+	}
+}
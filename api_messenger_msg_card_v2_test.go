@@ -0,0 +1,66 @@
+package feishu
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewMessageCardV2(t *testing.T) {
+	button := WithCardElementAction(WithCardElementPlainText("打开"), "https://www.feishu.cn",
+		WithCardElementActionButton(ButtonPrimary), WithCardElementActionValue(map[string]string{"k": "v"}))
+	actions := WithCardElementActions(button,
+		WithCardElementAction(WithCardElementPlainText("多端"), "",
+			WithCardElementActionMultiURL("https://a", "https://b", "https://c", "https://d")),
+	)
+	msg := NewMessageCardV2(
+		WithCardHeader("标题",
+			WithCardHeaderTemplate(BgColorBlue),
+			WithCardHeaderI18nTitle(LangEnglish, "title"),
+			WithCardHeaderSubtitle("副标题"),
+			WithCardHeaderTextTag("进行中", "blue"),
+			WithCardHeaderStandardIcon("chat_outlined", ""),
+		),
+		WithCardConfig(WithCardConfigStreamingMode(true, "生成中"), WithCardConfigWidthMode(CardWidthFill)),
+		WithCardElementMarkdown("**内容**"),
+		actions,
+		WithCardElementNote(WithCardElementPlainText("备注"), WithCardElementImage("img_v2_xxx")),
+		WithCardElementImage("img_v2_yyy", WithCardElementImageMode(ImageModeFitHorizontal)),
+	)
+	content, err := msg.marshalContent()
+	requireNil(t, err)
+
+	want := `{"body":{"elements":[` +
+		`{"tag":"div","text":{"content":"**内容**","tag":"lark_md"}},` +
+		`{"columns":[` +
+		`{"elements":[{"behaviors":[{"default_url":"https://www.feishu.cn","type":"open_url"},{"type":"callback","value":{"k":"v"}}],"tag":"button","text":{"content":"打开","tag":"plain_text"},"type":"primary"}],"tag":"column","vertical_align":"center","width":"auto"},` +
+		`{"elements":[{"behaviors":[{"android_url":"https://b","default_url":"https://a","ios_url":"https://c","pc_url":"https://d","type":"open_url"}],"tag":"button","text":{"content":"多端","tag":"plain_text"}}],"tag":"column","vertical_align":"center","width":"auto"}` +
+		`],"flex_mode":"flow","horizontal_spacing":"small","tag":"column_set"},` +
+		`{"columns":[` +
+		`{"elements":[{"tag":"div","text":{"content":"备注","tag":"plain_text","text_color":"grey","text_size":"notation"}}],"tag":"column","vertical_align":"center","width":"auto"},` +
+		`{"elements":[{"alt":{"content":"","tag":"plain_text"},"img_key":"img_v2_xxx","scale_type":"crop_center","size":"16px 16px","tag":"img"}],"tag":"column","vertical_align":"center","width":"auto"}` +
+		`],"flex_mode":"flow","horizontal_spacing":"small","tag":"column_set"},` +
+		`{"alt":{"content":"","tag":"plain_text"},"img_key":"img_v2_yyy","scale_type":"fit_horizontal","tag":"img"}` +
+		`]},` +
+		`"config":{"streaming_mode":true,"summary":{"content":"生成中"},"width_mode":"fill"},` +
+		`"header":{"icon":{"tag":"standard_icon","token":"chat_outlined"},"subtitle":{"content":"副标题","tag":"plain_text"},"template":"blue","text_tag_list":[{"color":"blue","tag":"text_tag","text":{"content":"进行中","tag":"plain_text"}}],"title":{"content":"标题","i18n_content":{"en_us":"title"},"tag":"plain_text"}},` +
+		`"schema":"2.0"}`
+	if content != want {
+		t.Errorf("content\n got: %s\nwant: %s", content, want)
+	}
+
+	// 原有元素不受转换影响
+	bs, err := json.Marshal(actions(false))
+	requireNil(t, err)
+	if !strings.Contains(string(bs), `"url":"https://www.feishu.cn"`) {
+		t.Errorf("legacy element modified: %s", bs)
+	}
+
+	tooManyTags := NewMessageCardV2(WithCardHeader("标题",
+		WithCardHeaderTextTag("1", ""), WithCardHeaderTextTag("2", ""),
+		WithCardHeaderTextTag("3", ""), WithCardHeaderTextTag("4", ""),
+	), nil, WithCardElementHorizontalRule())
+	if _, err = tooManyTags.marshalContent(); err == nil {
+		t.Error("expected error")
+	}
+}
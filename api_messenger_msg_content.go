@@ -0,0 +1,310 @@
+package feishu
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MessageType 消息类型
+type MessageType string
+
+const (
+	MsgTypeText         MessageType = "text"          // 文本
+	MsgTypePost         MessageType = "post"          // 富文本
+	MsgTypeImage        MessageType = "image"         // 图片
+	MsgTypeFile         MessageType = "file"          // 文件
+	MsgTypeAudio        MessageType = "audio"         // 音频
+	MsgTypeMedia        MessageType = "media"         // 视频
+	MsgTypeSticker      MessageType = "sticker"       // 表情包
+	MsgTypeInteractive  MessageType = "interactive"   // 消息卡片
+	MsgTypeShareChat    MessageType = "share_chat"    // 分享群名片
+	MsgTypeShareUser    MessageType = "share_user"    // 分享个人名片
+	MsgTypeMergeForward MessageType = "merge_forward" // 合并转发
+)
+
+// MessageContent 解析后的消息内容
+//  与 NewMessage* 构造的消息内容结构一致, 可通过 NewMessageFromContent 重新构造消息(接收到的卡片及合并转发除外)
+type MessageContent interface {
+	MsgType() MessageType
+}
+
+// DecodeMessageContent 将 EventMessage.Content, MessageBody.Content 解析为对应消息类型的结构
+//  MsgTypeText: MessageContentText
+//  MsgTypePost: MessageContentPost
+//  MsgTypeImage: MessageContentImage
+//  MsgTypeFile: MessageContentFile
+//  MsgTypeAudio: MessageContentAudio
+//  MsgTypeMedia: MessageContentMedia
+//  MsgTypeSticker: MessageContentSticker
+//  MsgTypeInteractive: MessageContentInteractive
+//  MsgTypeShareChat: MessageContentShareChat
+//  MsgTypeShareUser: MessageContentShareUser
+//  MsgTypeMergeForward: MessageContentMergeForward
+//  其他消息类型: MessageContentRaw
+func DecodeMessageContent(msgType MessageType, content string) (MessageContent, error) {
+	var v MessageContent
+	switch msgType {
+	case MsgTypeText:
+		v = new(MessageContentText)
+	case MsgTypePost:
+		v = new(MessageContentPost)
+	case MsgTypeImage:
+		v = new(MessageContentImage)
+	case MsgTypeFile:
+		v = new(MessageContentFile)
+	case MsgTypeAudio:
+		v = new(MessageContentAudio)
+	case MsgTypeMedia:
+		v = new(MessageContentMedia)
+	case MsgTypeSticker:
+		v = new(MessageContentSticker)
+	case MsgTypeInteractive:
+		v = new(MessageContentInteractive)
+	case MsgTypeShareChat:
+		v = new(MessageContentShareChat)
+	case MsgTypeShareUser:
+		v = new(MessageContentShareUser)
+	case MsgTypeMergeForward:
+		if !json.Valid([]byte(content)) {
+			// 获取消息时, 合并转发消息的内容为纯文本
+			return &MessageContentMergeForward{Content: content}, nil
+		}
+		v = new(MessageContentMergeForward)
+	default:
+		raw := json.RawMessage(content)
+		if !json.Valid(raw) {
+			raw, _ = json.Marshal(content)
+		}
+		return &MessageContentRaw{Type: msgType, Raw: raw}, nil
+	}
+
+	if err := json.Unmarshal([]byte(content), v); err != nil {
+		return nil, fmt.Errorf("decode message content(%s): %w", msgType, err)
+	}
+	return v, nil
+}
+
+// NewMessageFromContent 使用解析后的消息内容构造消息, 是 DecodeMessageContent 的逆操作
+//  以下内容无法重新发送, 发送时返回错误:
+//  - 接收到的消息卡片: 内容为只读的简化结构, 请使用 NewMessageCard 等重新构造
+//  - 合并转发: 请使用 MergeForwardMessage
+func NewMessageFromContent(c MessageContent) *Message {
+	msg := &Message{
+		msgType: string(c.MsgType()),
+		content: c,
+	}
+	switch c := c.(type) {
+	case *MessageContentInteractive:
+		if c.received {
+			msg.err = fmt.Errorf("message content(%s): received card content cannot be re-sent", c.MsgType())
+		}
+	case *MessageContentMergeForward:
+		msg.err = fmt.Errorf("message content(%s): cannot be re-sent, use MergeForwardMessage instead", c.MsgType())
+	}
+	return msg
+}
+
+// DecodeContent 解析消息内容, 参考 DecodeMessageContent
+func (m EventMessage) DecodeContent() (MessageContent, error) {
+	return DecodeMessageContent(MessageType(m.MessageType), m.Content)
+}
+
+// DecodeContent 解析消息内容, 参考 DecodeMessageContent
+func (m MessageDetail) DecodeContent() (MessageContent, error) {
+	return DecodeMessageContent(MessageType(m.MsgType), m.Body.Content)
+}
+
+// MessageContentText 文本, 对应 NewMessageText
+type MessageContentText struct {
+	Text string `json:"text"`
+}
+
+func (*MessageContentText) MsgType() MessageType { return MsgTypeText }
+
+// MessageContentPost 富文本, 对应 NewMessagePost
+//  接收到的富文本消息不区分语言环境, 内容在 PostContent 中, 重新发送时使用 LangChinese
+//  包含多个语言环境时, 内容在 I18n 中
+type MessageContentPost struct {
+	PostContent
+	I18n map[Language]PostContent
+}
+
+func (*MessageContentPost) MsgType() MessageType { return MsgTypePost }
+
+type PostContent struct {
+	Title   string                 `json:"title"`
+	Content [][]PostContentElement `json:"content"` // 段落及段落内的元素
+}
+
+// PostContentElement 富文本中的元素, 根据 Tag 区分元素类型
+type PostContentElement struct {
+	Tag       string   `json:"tag"`                  // text, a, at, img, media, emotion, code_block, hr, md
+	Text      string   `json:"text,omitempty"`       // text, a, code_block, md
+	UnEscape  bool     `json:"un_escape,omitempty"`  // text
+	Href      string   `json:"href,omitempty"`       // a
+	UserID    string   `json:"user_id,omitempty"`    // at
+	UserName  string   `json:"user_name,omitempty"`  // at
+	ImageKey  string   `json:"image_key,omitempty"`  // img, media
+	FileKey   string   `json:"file_key,omitempty"`   // media
+	EmojiType string   `json:"emoji_type,omitempty"` // emotion
+	Language  string   `json:"language,omitempty"`   // code_block
+	Style     []string `json:"style,omitempty"`      // text, a, at
+}
+
+func (c MessageContentPost) MarshalJSON() ([]byte, error) {
+	if len(c.I18n) != 0 {
+		return json.Marshal(c.I18n)
+	}
+	// 发送富文本消息时内容必须区分语言环境
+	return json.Marshal(map[Language]PostContent{LangChinese: c.PostContent})
+}
+
+func (c *MessageContentPost) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if _, ok := m["content"]; ok {
+		return json.Unmarshal(data, &c.PostContent)
+	}
+
+	c.I18n = make(map[Language]PostContent, len(m))
+	for lang, raw := range m {
+		var pc PostContent
+		if err := json.Unmarshal(raw, &pc); err != nil {
+			return err
+		}
+		c.I18n[Language(lang)] = pc
+	}
+	return nil
+}
+
+// MessageContentImage 图片, 对应 NewMessageImage
+type MessageContentImage struct {
+	ImageKey string `json:"image_key"`
+}
+
+func (*MessageContentImage) MsgType() MessageType { return MsgTypeImage }
+
+// MessageContentFile 文件, 对应 NewMessageFile
+type MessageContentFile struct {
+	FileKey  string `json:"file_key"`
+	FileName string `json:"file_name,omitempty"` // 仅接收消息时有值
+}
+
+func (*MessageContentFile) MsgType() MessageType { return MsgTypeFile }
+
+// MessageContentAudio 音频, 对应 NewMessageAudio
+type MessageContentAudio struct {
+	FileKey  string `json:"file_key"`
+	Duration int    `json:"duration,omitempty"` // 时长（毫秒）, 仅接收消息时有值
+}
+
+func (*MessageContentAudio) MsgType() MessageType { return MsgTypeAudio }
+
+// MessageContentMedia 视频, 对应 NewMessageMedia
+type MessageContentMedia struct {
+	FileKey  string `json:"file_key"`
+	ImageKey string `json:"image_key"`           // 视频封面图片
+	FileName string `json:"file_name,omitempty"` // 仅接收消息时有值
+	Duration int    `json:"duration,omitempty"`  // 时长（毫秒）, 仅接收消息时有值
+}
+
+func (*MessageContentMedia) MsgType() MessageType { return MsgTypeMedia }
+
+// MessageContentSticker 表情包, 对应 NewMessageSticker
+type MessageContentSticker struct {
+	FileKey string `json:"file_key"`
+}
+
+func (*MessageContentSticker) MsgType() MessageType { return MsgTypeSticker }
+
+// MessageContentInteractive 消息卡片, 对应 NewMessageCard
+//  卡片结构复杂, 仅解析标题, 完整内容保留在 Raw 中
+//  接收到的卡片内容为只读的简化结构, 无法通过 NewMessageFromContent 重新发送
+type MessageContentInteractive struct {
+	Title string
+	Raw   json.RawMessage
+
+	received bool // 内容为接收到的简化结构
+}
+
+func (*MessageContentInteractive) MsgType() MessageType { return MsgTypeInteractive }
+
+func (c MessageContentInteractive) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return []byte("{}"), nil
+	}
+	return c.Raw, nil
+}
+
+func (c *MessageContentInteractive) UnmarshalJSON(data []byte) error {
+	var v struct {
+		// 接收到的卡片消息, elements 为按行分组的二维数组
+		Title    string          `json:"title"`
+		Elements json.RawMessage `json:"elements"`
+		// 发送的卡片消息
+		Header *struct {
+			Title struct {
+				Content string            `json:"content"`
+				I18n    map[string]string `json:"i18n"`
+			} `json:"title"`
+		} `json:"header"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	c.Title = v.Title
+	var rows [][]json.RawMessage
+	c.received = v.Title != "" || (len(v.Elements) > 0 && json.Unmarshal(v.Elements, &rows) == nil && len(rows) > 0)
+	if c.Title == "" && v.Header != nil {
+		c.Title = v.Header.Title.Content
+		for _, lang := range []Language{LangChinese, LangEnglish, LangJapanese} {
+			if c.Title != "" {
+				break
+			}
+			c.Title = v.Header.Title.I18n[string(lang)]
+		}
+	}
+	c.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MessageContentShareChat 分享群名片, 对应 NewMessageShareChat
+type MessageContentShareChat struct {
+	ChatID string `json:"chat_id"`
+}
+
+func (*MessageContentShareChat) MsgType() MessageType { return MsgTypeShareChat }
+
+// MessageContentShareUser 分享个人名片, 对应 NewMessageShareUser
+type MessageContentShareUser struct {
+	UserID string `json:"user_id"`
+}
+
+func (*MessageContentShareUser) MsgType() MessageType { return MsgTypeShareUser }
+
+// MessageContentMergeForward 合并转发
+//  子消息需通过 GetMessage 获取, 子消息的 MessageDetail.UpperMessageID 为该消息的 ID
+//  不支持直接发送, 请使用 MergeForwardMessage
+type MessageContentMergeForward struct {
+	Content string `json:"content"`
+}
+
+func (*MessageContentMergeForward) MsgType() MessageType { return MsgTypeMergeForward }
+
+// MessageContentRaw 未支持解析的消息类型, 保留原始内容
+type MessageContentRaw struct {
+	Type MessageType
+	Raw  json.RawMessage
+}
+
+func (c *MessageContentRaw) MsgType() MessageType { return c.Type }
+
+func (c MessageContentRaw) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return []byte("{}"), nil
+	}
+	return c.Raw, nil
+}
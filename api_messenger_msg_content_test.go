@@ -0,0 +1,100 @@
+package feishu
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeMessageContent(t *testing.T) {
+	msgs := []*Message{
+		NewMessageText("first line\n" + StrMentionAll()),
+		NewMessagePost(
+			WithPost(LangChinese, "标题",
+				WithPostElementText("第一行"),
+				WithPostElementLink("超链接", "https://www.feishu.cn"),
+				WithPostElementImage("img_7ea74629-9191-4176-998c-2e603c9c5e8g"),
+				WithPostElementMentionByOpenID("ou_c99c5f35d542efc7ee492afe11af19ef", "name"),
+			),
+			WithPost(LangEnglish, "title",
+				WithPostElementText("first line", true),
+			),
+		),
+		NewMessageImage("img_7ea74629-9191-4176-998c-2e603c9c5e8g"),
+		NewMessageCard(BgColorGreen, nil, WithCard(LangChinese, "标题", WithCardElementPlainText("内容"))),
+		NewMessageShareChat("oc_78b297d4a002835dd4eeafe6f83d7b69"),
+		NewMessageShareUser("ou_c99c5f35d542efc7ee492afe11af19ef"),
+		NewMessageAudio("75235e0c-4f92-430a-a99b-8446610223cg"),
+		NewMessageMedia("75235e0c-4f92-430a-a99b-8446610223cg", "img_7ea74629-9191-4176-998c-2e603c9c5e8g"),
+		NewMessageFile("75235e0c-4f92-430a-a99b-8446610223cg"),
+		NewMessageSticker("75235e0c-4f92-430a-a99b-8446610223cg"),
+	}
+
+	for _, msg := range msgs {
+		t.Run(msg.msgType, func(t *testing.T) {
+			content, err := msg.marshalContent()
+			requireNil(t, err)
+
+			c, err := DecodeMessageContent(MessageType(msg.msgType), content)
+			requireNil(t, err)
+			if c.MsgType() != MessageType(msg.msgType) {
+				t.Fatalf("MsgType() = %s, want %s", c.MsgType(), msg.msgType)
+			}
+
+			got, err := NewMessageFromContent(c).marshalContent()
+			requireNil(t, err)
+
+			var want, actual interface{}
+			requireNil(t, json.Unmarshal([]byte(content), &want))
+			requireNil(t, json.Unmarshal([]byte(got), &actual))
+			if !reflect.DeepEqual(want, actual) {
+				t.Fatalf("round trip mismatch:\nwant: %s\n got: %s", content, got)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageContent_received(t *testing.T) {
+	c, err := DecodeMessageContent(MsgTypePost, `{"title":"标题","content":[[{"tag":"text","text":"hi ","style":["bold"]},{"tag":"at","user_id":"@_user_1","user_name":"Tom"}],[{"tag":"img","image_key":"img_1"}]]}`)
+	requireNil(t, err)
+	post := c.(*MessageContentPost)
+	if post.Title != "标题" || len(post.Content) != 2 || post.Content[0][1].UserName != "Tom" || post.Content[0][0].Style[0] != "bold" {
+		t.Fatalf("unexpected post: %+v", post)
+	}
+	got, err := NewMessageFromContent(c).marshalContent()
+	requireNil(t, err)
+	if want := `{"zh_cn":{"title":"标题","content":[[{"tag":"text","text":"hi ","style":["bold"]},{"tag":"at","user_id":"@_user_1","user_name":"Tom"}],[{"tag":"img","image_key":"img_1"}]]}}`; got != want {
+		t.Fatalf("re-sent post\n got: %s\nwant: %s", got, want)
+	}
+
+	c, err = DecodeMessageContent(MsgTypeInteractive, `{"title":"卡片","elements":[[{"tag":"text","text":"内容"}]]}`)
+	requireNil(t, err)
+	if title := c.(*MessageContentInteractive).Title; title != "卡片" {
+		t.Fatalf("Title = %q", title)
+	}
+	if _, err = NewMessageFromContent(c).marshalContent(); err == nil {
+		t.Fatal("expected an error for re-sending a received card")
+	}
+
+	c, err = DecodeMessageContent(MsgTypeMergeForward, "Merged and Forwarded Message")
+	requireNil(t, err)
+	if content := c.(*MessageContentMergeForward).Content; content != "Merged and Forwarded Message" {
+		t.Fatalf("Content = %q", content)
+	}
+	if _, err = NewMessageFromContent(c).marshalContent(); err == nil {
+		t.Fatal("expected an error for re-sending a merge_forward message")
+	}
+
+	c, err = DecodeMessageContent("location", `{"name":"somewhere"}`)
+	requireNil(t, err)
+	if raw := c.(*MessageContentRaw); raw.Type != "location" || string(raw.Raw) != `{"name":"somewhere"}` {
+		t.Fatalf("unexpected raw: %+v", raw)
+	}
+
+	msg := EventMessage{MessageType: "file", Content: `{"file_key":"file_1","file_name":"ci.log"}`}
+	c, err = msg.DecodeContent()
+	requireNil(t, err)
+	if file := c.(*MessageContentFile); file.FileName != "ci.log" {
+		t.Fatalf("unexpected file: %+v", file)
+	}
+}
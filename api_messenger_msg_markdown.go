@@ -0,0 +1,578 @@
+package feishu
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrMarkdownUnsupported 严格模式下遇到不支持的 Markdown 语法
+var ErrMarkdownUnsupported = errors.New("unsupported markdown syntax")
+
+type markdownOption struct {
+	uploadImage    func(src string) (imageKey string, err error)
+	resolveMention func(name string) (openID string, ok bool)
+	strict         bool
+}
+
+type MarkdownOption func(*markdownOption)
+
+// WithMarkdownImageUploader 图片地址不是 image_key（img_ 开头）时, 调用 upload 上传并返回 image_key
+//  未设置时, 此类图片转换为文字超链接
+func WithMarkdownImageUploader(upload func(src string) (imageKey string, err error)) MarkdownOption {
+	return func(opt *markdownOption) {
+		opt.uploadImage = upload
+	}
+}
+
+// WithMarkdownMentionResolver 将 @name 解析为 @用户, resolve 返回 false 时保留原文
+//  未设置时仅识别 <at user_id="ou_xxx"></at> 及 <at id=ou_xxx></at>
+func WithMarkdownMentionResolver(resolve func(name string) (openID string, ok bool)) MarkdownOption {
+	return func(opt *markdownOption) {
+		opt.resolveMention = resolve
+	}
+}
+
+// WithMarkdownStrict 遇到不支持的语法时返回 ErrMarkdownUnsupported, 默认原样保留为普通文本
+func WithMarkdownStrict() MarkdownOption {
+	return func(opt *markdownOption) {
+		opt.strict = true
+	}
+}
+
+// MarkdownToPost 将 Markdown（CommonMark 子集）转换为富文本消息
+//  支持语法如下:
+//  段落: 连续的行合并为一段, 行尾两个空格或 `\` 表示换行
+//  标题: 转换为加粗段落
+//  加粗/斜体/删除线: **bold** __bold__ *italic* _italic_ ~~strike~~
+//  行内代码: 保留反引号, 内部不再解析
+//  代码块: ``` 或 ~~~ 围起的代码, 转换为 code_block 段落
+//  列表: - * + 及 1. 1) 开头的列表项, 每项一个段落, 缩进表示嵌套
+//  链接: [text](href) 及 <https://...>
+//  图片: ![alt](src), 单独成段, 参见 WithMarkdownImageUploader
+//  @用户: <at user_id="ou_xxx">name</at>, 参见 WithMarkdownMentionResolver
+//  分割线: --- *** ___
+//  引用、表格、HTML 等不支持的语法原样保留为普通文本, 参见 WithMarkdownStrict
+func MarkdownToPost(lang Language, title, md string, opts ...MarkdownOption) (Post, error) {
+	c := &markdownConverter{}
+	for _, fn := range opts {
+		fn(&c.opt)
+	}
+	if err := c.convert(md); err != nil {
+		return nil, err
+	}
+	paragraphs := c.paragraphs
+	return func() i18nPost {
+		return i18nPost{
+			lang:     string(lang),
+			title:    title,
+			elements: paragraphs,
+		}
+	}, nil
+}
+
+type markdownConverter struct {
+	opt        markdownOption
+	paragraphs [][]interface{}
+}
+
+const _mdEscapable = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+var (
+	_mdHeading     = regexp.MustCompile(`^#{1,6}(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	_mdBulletItem  = regexp.MustCompile(`^([-*+])[ \t]+(.*)$`)
+	_mdOrderedItem = regexp.MustCompile(`^([0-9]{1,9})([.)])[ \t]+(.*)$`)
+	_mdThematic    = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	_mdAtTag       = regexp.MustCompile(`^<at[ \t]+(?:user_id|id)=["']?([^"'\s>]+)["']?[ \t]*>(.*?)</at>`)
+	_mdAutolink    = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]+)>`)
+)
+
+func (c *markdownConverter) convert(md string) error {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	var para []string
+	flush := func() error {
+		if len(para) == 0 {
+			return nil
+		}
+		err := c.addLines("", para)
+		para = nil
+		return err
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		indent, trimmed := markdownIndent(line)
+
+		if trimmed == "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			continue
+		}
+		if indent >= 4 && len(para) != 0 {
+			// 缩进的行属于当前段落
+			para = append(para, trimmed)
+			continue
+		}
+
+		if fence := markdownFence(trimmed); fence != "" {
+			if err := flush(); err != nil {
+				return err
+			}
+			language := strings.TrimSpace(trimmed[len(fence):])
+			code := make([]string, 0)
+			for i++; i < len(lines); i++ {
+				if _, t := markdownIndent(lines[i]); strings.HasPrefix(t, fence) && strings.TrimLeft(t, fence[:1]) == "" {
+					break
+				}
+				code = append(code, strings.TrimPrefix(lines[i], strings.Repeat(" ", indent)))
+			}
+			c.addParagraph(newPostElementCodeBlock(language, strings.Join(code, "\n")))
+			continue
+		}
+
+		if m := _mdHeading.FindStringSubmatch(trimmed); m != nil {
+			if err := flush(); err != nil {
+				return err
+			}
+			elements, err := c.parseInline(m[1], []PostTextStyle{PostTextBold})
+			if err != nil {
+				return err
+			}
+			c.addParagraph(elements...)
+			continue
+		}
+
+		if _mdThematic.MatchString(trimmed) {
+			if err := flush(); err != nil {
+				return err
+			}
+			c.addParagraph(WithPostElementHorizontalRule()())
+			continue
+		}
+
+		if markdownUnsupportedBlock(trimmed) {
+			if c.opt.strict {
+				return fmt.Errorf("%w at line %d: %q", ErrMarkdownUnsupported, i+1, trimmed)
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			c.addParagraph(newPostElementText(trimmed, nil))
+			continue
+		}
+
+		if prefix, content, ok := markdownListItem(indent, trimmed); ok {
+			if err := flush(); err != nil {
+				return err
+			}
+			item := []string{content}
+			// 缩进比列表符号多的非列表行属于当前列表项
+			for i+1 < len(lines) {
+				nextIndent, next := markdownIndent(lines[i+1])
+				if next == "" || nextIndent <= indent || markdownFence(next) != "" {
+					break
+				}
+				if _, _, isItem := markdownListItem(nextIndent, next); isItem {
+					break
+				}
+				item = append(item, next)
+				i++
+			}
+			if err := c.addLines(prefix, item); err != nil {
+				return err
+			}
+			continue
+		}
+
+		para = append(para, line)
+	}
+	return flush()
+}
+
+// addLines 将若干行合并为段落, 硬换行处另起一段
+func (c *markdownConverter) addLines(prefix string, lines []string) error {
+	var sb strings.Builder
+	for i, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		hardBreak := strings.HasSuffix(line, "  ") || (strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`))
+		if strings.HasSuffix(line, `\`) && hardBreak {
+			line = line[:len(line)-1]
+		}
+		line = strings.TrimRight(line, " \t")
+		if sb.Len() != 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(line)
+
+		if hardBreak || i == len(lines)-1 {
+			elements, err := c.parseInline(sb.String(), nil)
+			if err != nil {
+				return err
+			}
+			if prefix != "" {
+				elements = prependPostText(prefix, elements)
+				prefix = ""
+			}
+			c.addParagraph(elements...)
+			sb.Reset()
+		}
+	}
+	return nil
+}
+
+// addParagraph 添加段落, 图片、代码块等元素单独成段
+func (c *markdownConverter) addParagraph(elements ...postElement) {
+	c.paragraphs = appendPostParagraph(c.paragraphs, elements)
+}
+
+func (c *markdownConverter) parseInline(s string, style []PostTextStyle) ([]postElement, error) {
+	var (
+		elements []postElement
+		buf      strings.Builder
+	)
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		elements = appendPostText(elements, buf.String(), style)
+		buf.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == '\\' && i+1 < len(s) && strings.IndexByte(_mdEscapable, s[i+1]) >= 0:
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case ch == '`':
+			n := markdownRun(s, i, '`')
+			if end := strings.Index(s[i+n:], s[i:i+n]); end >= 0 {
+				flush()
+				elements = appendPostText(elements, s[i:i+n+end+n], style)
+				i += n + end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case ch == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, href, n, ok := markdownLink(s[i+1:]); ok {
+				elem, err := c.image(text, href, style)
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				elements = append(elements, elem)
+				i += 1 + n
+				continue
+			}
+
+		case ch == '[':
+			if text, href, n, ok := markdownLink(s[i:]); ok {
+				inner, err := c.parseInline(text, style)
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				for _, elem := range inner {
+					if t, ok := elem.elem.(postText); ok {
+						elem = newPostElementLink(t.Text, href, t.Style)
+					}
+					elements = append(elements, elem)
+				}
+				i += n
+				continue
+			}
+
+		case ch == '<':
+			if m := _mdAtTag.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				if m[1] == "all" {
+					elements = append(elements, WithPostElementMentionAll()())
+				} else if m[2] != "" {
+					elements = append(elements, WithPostElementMentionByOpenID(m[1], m[2])())
+				} else {
+					elements = append(elements, WithPostElementMentionByOpenID(m[1])())
+				}
+				i += len(m[0])
+				continue
+			}
+			if m := _mdAutolink.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				elements = append(elements, newPostElementLink(m[1], m[1], style))
+				i += len(m[0])
+				continue
+			}
+
+		case ch == '*' || ch == '_' || ch == '~':
+			n := markdownRun(s, i, ch)
+			if end, ok := markdownEmphasis(s, i, n); ok {
+				inner, err := c.parseInline(s[i+n:end], markdownEmphasisStyle(style, ch, n))
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				elements = append(elements, inner...)
+				i = end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case ch == '@' && c.opt.resolveMention != nil && (i == 0 || s[i-1] == ' '):
+			n := 1
+			for n < len(s)-i {
+				r, size := utf8.DecodeRuneInString(s[i+n:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+					break
+				}
+				n += size
+			}
+			name := strings.TrimRight(s[i+1:i+n], ".")
+			if openID, ok := c.opt.resolveMention(name); ok && name != "" {
+				flush()
+				elements = append(elements, WithPostElementMentionByOpenID(openID, name)())
+				i += 1 + len(name)
+				continue
+			}
+		}
+		buf.WriteByte(ch)
+		i++
+	}
+	flush()
+	return elements, nil
+}
+
+func (c *markdownConverter) image(alt, src string, style []PostTextStyle) (postElement, error) {
+	if strings.HasPrefix(src, "img_") {
+		return newPostElementImage(src), nil
+	}
+	if c.opt.uploadImage != nil {
+		imageKey, err := c.opt.uploadImage(src)
+		if err != nil {
+			return postElement{}, fmt.Errorf("upload markdown image %q: %w", src, err)
+		}
+		return newPostElementImage(imageKey), nil
+	}
+	if c.opt.strict {
+		return postElement{}, fmt.Errorf("%w: image %q without uploader", ErrMarkdownUnsupported, src)
+	}
+	if alt == "" {
+		alt = src
+	}
+	return newPostElementLink(alt, src, style), nil
+}
+
+// appendPostText 追加文字元素, 与前一个样式相同的文字元素合并
+func appendPostText(elements []postElement, text string, style []PostTextStyle) []postElement {
+	if n := len(elements); n != 0 {
+		if t, ok := elements[n-1].elem.(postText); ok {
+			if reflect.DeepEqual(t.Style, style) || len(t.Style) == 0 && len(style) == 0 {
+				t.Text += text
+				elements[n-1].elem = t
+				return elements
+			}
+		}
+	}
+	return append(elements, newPostElementText(text, style))
+}
+
+// prependPostText 在段落前插入无样式文字, 与第一个无样式文字元素合并
+func prependPostText(text string, elements []postElement) []postElement {
+	if len(elements) != 0 {
+		if t, ok := elements[0].elem.(postText); ok && len(t.Style) == 0 {
+			t.Text = text + t.Text
+			elements[0].elem = t
+			return elements
+		}
+	}
+	return append([]postElement{newPostElementText(text, nil)}, elements...)
+}
+
+func markdownIndent(line string) (int, string) {
+	indent := 0
+	for i, r := range line {
+		switch r {
+		case ' ':
+			indent++
+		case '\t':
+			indent += 4 - indent%4
+		default:
+			return indent, strings.TrimRight(line[i:], " \t")
+		}
+	}
+	return indent, ""
+}
+
+func markdownFence(trimmed string) string {
+	for _, ch := range []byte{'`', '~'} {
+		if n := markdownRun(trimmed, 0, ch); n >= 3 {
+			if ch == '`' && strings.ContainsRune(trimmed[n:], '`') {
+				return ""
+			}
+			return trimmed[:n]
+		}
+	}
+	return ""
+}
+
+func markdownUnsupportedBlock(trimmed string) bool {
+	switch trimmed[0] {
+	case '>', '|':
+		return true
+	case '<':
+		return !_mdAtTag.MatchString(trimmed) && !_mdAutolink.MatchString(trimmed)
+	}
+	return false
+}
+
+// markdownListItem 返回列表项的前缀及内容, 每两个空格的缩进为一级嵌套
+func markdownListItem(indent int, trimmed string) (prefix, content string, ok bool) {
+	pad := strings.Repeat("  ", indent/2)
+	if m := _mdBulletItem.FindStringSubmatch(trimmed); m != nil && !_mdThematic.MatchString(trimmed) {
+		bullets := []string{"• ", "◦ ", "▪ "}
+		level := indent / 2
+		if level >= len(bullets) {
+			level = len(bullets) - 1
+		}
+		return pad + bullets[level], m[2], true
+	}
+	if m := _mdOrderedItem.FindStringSubmatch(trimmed); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return pad + strconv.Itoa(n) + ". ", m[3], true
+	}
+	return "", "", false
+}
+
+// markdownLink 解析 [text](href "title"), n 为消耗的字节数
+func markdownLink(s string) (text, href string, n int, ok bool) {
+	depth := 0
+	closeBracket := -1
+	for i := 0; i < len(s) && closeBracket < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeBracket = i
+			}
+		}
+	}
+	if closeBracket < 0 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+	depth = 0
+	for i := closeBracket + 1; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				dest := strings.TrimSpace(s[closeBracket+2 : i])
+				if fields := strings.Fields(dest); len(fields) != 0 {
+					dest = fields[0]
+				}
+				dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+				if dest == "" {
+					return "", "", 0, false
+				}
+				return s[1:closeBracket], dest, i + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+func markdownRun(s string, i int, ch byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == ch {
+		n++
+	}
+	return n
+}
+
+// markdownEmphasis 查找与 s[i:i+n] 匹配的结束符位置
+func markdownEmphasis(s string, i, n int) (int, bool) {
+	ch := s[i]
+	if n > 3 || (ch == '~' && n != 2) {
+		return 0, false
+	}
+	start := i + n
+	if start >= len(s) || s[start] == ' ' {
+		return 0, false
+	}
+	if ch == '_' && i > 0 && isMarkdownWordByte(s[i-1]) {
+		return 0, false
+	}
+	// 优先匹配长度相同的结束符, 否则取更长结束符的末尾部分, 如 **bold *italic***
+	for _, exact := range []bool{true, false} {
+		for j := start; j < len(s); {
+			switch s[j] {
+			case '\\':
+				j += 2
+				continue
+			case '`':
+				m := markdownRun(s, j, '`')
+				if end := strings.Index(s[j+m:], s[j:j+m]); end >= 0 {
+					j += m + end + m
+					continue
+				}
+				j += m
+				continue
+			case ch:
+				m := markdownRun(s, j, ch)
+				if (m == n || !exact && m > n) && j > start && s[j-1] != ' ' &&
+					!(ch == '_' && j+m < len(s) && isMarkdownWordByte(s[j+m])) {
+					return j + m - n, true
+				}
+				j += m
+				continue
+			}
+			j++
+		}
+	}
+	return 0, false
+}
+
+func markdownEmphasisStyle(style []PostTextStyle, ch byte, n int) []PostTextStyle {
+	st := append(make([]PostTextStyle, 0, len(style)+2), style...)
+	add := func(s PostTextStyle) {
+		for _, v := range st {
+			if v == s {
+				return
+			}
+		}
+		st = append(st, s)
+	}
+	switch {
+	case ch == '~':
+		add(PostTextLineThrough)
+	case n == 1:
+		add(PostTextItalic)
+	case n == 2:
+		add(PostTextBold)
+	default:
+		add(PostTextBold)
+		add(PostTextItalic)
+	}
+	return st
+}
+
+func isMarkdownWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= utf8.RuneSelf
+}
@@ -0,0 +1,119 @@
+package feishu
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMarkdownToPost(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		opts []MarkdownOption
+		want string
+	}{
+		{
+			name: "paragraph",
+			md:   "first line\nsame paragraph  \nsecond line\n\nthird\\\nfourth",
+			want: `[[{"tag":"text","text":"first line same paragraph"}],[{"tag":"text","text":"second line"}],[{"tag":"text","text":"third"}],[{"tag":"text","text":"fourth"}]]`,
+		},
+		{
+			name: "emphasis",
+			md:   "a **bold** _italic_ ***both*** ~~gone~~ snake_case_name 2 * 3 \\*x\\*",
+			want: `[[{"tag":"text","text":"a "},{"style":["bold"],"tag":"text","text":"bold"},{"tag":"text","text":" "},{"style":["italic"],"tag":"text","text":"italic"},{"tag":"text","text":" "},{"style":["bold","italic"],"tag":"text","text":"both"},{"tag":"text","text":" "},{"style":["lineThrough"],"tag":"text","text":"gone"},{"tag":"text","text":" snake_case_name 2 * 3 *x*"}]]`,
+		},
+		{
+			name: "nested emphasis",
+			md:   "**bold *and italic***",
+			want: `[[{"style":["bold"],"tag":"text","text":"bold "},{"style":["bold","italic"],"tag":"text","text":"and italic"}]]`,
+		},
+		{
+			name: "inline code",
+			md:   "run `go test **./...**` now",
+			want: `[[{"tag":"text","text":"run ` + "`go test **./...**`" + ` now"}]]`,
+		},
+		{
+			name: "link",
+			md:   "see [the **docs**](https://open.feishu.cn \"title\") or <https://www.feishu.cn>",
+			want: `[[{"tag":"text","text":"see "},{"href":"https://open.feishu.cn","tag":"a","text":"the "},{"href":"https://open.feishu.cn","style":["bold"],"tag":"a","text":"docs"},{"tag":"text","text":" or "},{"href":"https://www.feishu.cn","tag":"a","text":"https://www.feishu.cn"}]]`,
+		},
+		{
+			name: "heading",
+			md:   "## Release *v1.2* ##",
+			want: `[[{"style":["bold"],"tag":"text","text":"Release "},{"style":["bold","italic"],"tag":"text","text":"v1.2"}]]`,
+		},
+		{
+			name: "code block",
+			md:   "```go\nfmt.Println(\"**hi**\")\n\n```\nafter",
+			want: `[[{"language":"go","tag":"code_block","text":"fmt.Println(\"**hi**\")\n"}],[{"tag":"text","text":"after"}]]`,
+		},
+		{
+			name: "list",
+			md:   "- one\n  continued\n  - nested\n3. three\n4) four",
+			want: `[[{"tag":"text","text":"• one continued"}],[{"tag":"text","text":"  ◦ nested"}],[{"tag":"text","text":"3. three"}],[{"tag":"text","text":"4. four"}]]`,
+		},
+		{
+			name: "image key",
+			md:   "before ![logo](img_v2_xxx) after",
+			want: `[[{"tag":"text","text":"before "}],[{"image_key":"img_v2_xxx","tag":"img"}],[{"tag":"text","text":" after"}]]`,
+		},
+		{
+			name: "image without uploader",
+			md:   "![logo](https://example.com/logo.png)",
+			want: `[[{"href":"https://example.com/logo.png","tag":"a","text":"logo"}]]`,
+		},
+		{
+			name: "image with uploader",
+			md:   "![](https://example.com/logo.png)",
+			opts: []MarkdownOption{WithMarkdownImageUploader(func(src string) (string, error) {
+				return "img_uploaded", nil
+			})},
+			want: `[[{"image_key":"img_uploaded","tag":"img"}]]`,
+		},
+		{
+			name: "mention",
+			md:   `hi <at user_id="ou_123">Tom</at> <at id=all></at> @jerry @nobody`,
+			opts: []MarkdownOption{WithMarkdownMentionResolver(func(name string) (string, bool) {
+				return "ou_456", name == "jerry"
+			})},
+			want: `[[{"tag":"text","text":"hi "},{"tag":"at","user_id":"ou_123","user_name":"Tom"},{"tag":"text","text":" "},{"tag":"at","user_id":"all"},{"tag":"text","text":" "},{"tag":"at","user_id":"ou_456","user_name":"jerry"},{"tag":"text","text":" @nobody"}]]`,
+		},
+		{
+			name: "unsupported",
+			md:   "> quote **x**\n\n---\n| a | b |",
+			want: `[[{"tag":"text","text":"\u003e quote **x**"}],[{"tag":"hr"}],[{"tag":"text","text":"| a | b |"}]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, err := MarkdownToPost(LangEnglish, "title", tt.md, tt.opts...)
+			requireNil(t, err)
+			p := post()
+			if p.lang != string(LangEnglish) || p.title != "title" {
+				t.Fatalf("unexpected post header: %q %q", p.lang, p.title)
+			}
+			bs, err := json.Marshal(p.elements)
+			requireNil(t, err)
+			if string(bs) != tt.want {
+				t.Errorf("MarkdownToPost()\n got: %s\nwant: %s", bs, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownToPost_error(t *testing.T) {
+	for _, md := range []string{"> quote", "| a | b |", "<div>html</div>", "![logo](https://example.com/logo.png)"} {
+		if _, err := MarkdownToPost(LangEnglish, "", md, WithMarkdownStrict()); !errors.Is(err, ErrMarkdownUnsupported) {
+			t.Errorf("MarkdownToPost(%q) error = %v, want ErrMarkdownUnsupported", md, err)
+		}
+	}
+
+	errUpload := errors.New("upload failed")
+	_, err := MarkdownToPost(LangEnglish, "", "![logo](a.png)", WithMarkdownImageUploader(func(string) (string, error) {
+		return "", errUpload
+	}))
+	if !errors.Is(err, errUpload) {
+		t.Errorf("MarkdownToPost() error = %v, want %v", err, errUpload)
+	}
+}
@@ -0,0 +1,578 @@
+package feishu
+
+import (
+	"encoding/json"
+)
+
+// 消息内容的结构体
+//  字段按 JSON 键名的字典序声明, 序列化结果与按键排序的 map 一致
+
+// ⬇️ ---------------------------------------- 富文本 post ---------------------------------------- ⬇️
+
+type postBody struct {
+	Content [][]interface{} `json:"content"`
+	Title   string          `json:"title"`
+}
+
+type postText struct {
+	Style    []PostTextStyle `json:"style,omitempty"`
+	Tag      string          `json:"tag"`
+	Text     string          `json:"text"`
+	UnEscape bool            `json:"un_escape,omitempty"`
+}
+
+type postLink struct {
+	Href  string          `json:"href"`
+	Style []PostTextStyle `json:"style,omitempty"`
+	Tag   string          `json:"tag"`
+	Text  string          `json:"text"`
+}
+
+type postAt struct {
+	Tag      string `json:"tag"`
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name,omitempty"`
+}
+
+type postImage struct {
+	ImageKey string `json:"image_key"`
+	Tag      string `json:"tag"`
+}
+
+type postEmotion struct {
+	EmojiType EmojiType `json:"emoji_type"`
+	Tag       string    `json:"tag"`
+}
+
+type postMedia struct {
+	FileKey  string `json:"file_key"`
+	ImageKey string `json:"image_key,omitempty"`
+	Tag      string `json:"tag"`
+}
+
+type postCodeBlock struct {
+	Language string `json:"language,omitempty"`
+	Tag      string `json:"tag"`
+	Text     string `json:"text"`
+}
+
+type postHR struct {
+	Tag string `json:"tag"`
+}
+
+type postMarkdown struct {
+	Tag  string `json:"tag"`
+	Text string `json:"text"`
+}
+
+// ⬆️ ---------------------------------------- 富文本 post ---------------------------------------- ⬆️
+
+// ⬇️ ---------------------------------------- 消息卡片 interactive ---------------------------------------- ⬇️
+
+type msgCard struct {
+	CardLink     *msgCardLink             `json:"card_link,omitempty"`
+	Config       *msgCardConfig           `json:"config,omitempty"`
+	Header       *msgCardHeader           `json:"header"`
+	I18nElements map[string][]interface{} `json:"i18n_elements"`
+}
+
+type msgCardV2 struct {
+	Body     msgCardBody    `json:"body"`
+	CardLink *msgCardLink   `json:"card_link,omitempty"`
+	Config   *msgCardConfig `json:"config,omitempty"`
+	Header   *cardHeaderV2  `json:"header,omitempty"`
+	Schema   string         `json:"schema"`
+}
+
+type msgCardBody struct {
+	Elements []interface{} `json:"elements"`
+}
+
+type msgCardConfig struct {
+	EnableForward *bool           `json:"enable_forward,omitempty"`
+	StreamingMode *bool           `json:"streaming_mode,omitempty"`
+	Summary       *msgCardSummary `json:"summary,omitempty"`
+	UpdateMulti   *bool           `json:"update_multi,omitempty"`
+	WidthMode     string          `json:"width_mode,omitempty"`
+}
+
+type msgCardSummary struct {
+	Content string `json:"content"`
+}
+
+// msgCardLink 卡片及按钮的多端跳转链接
+type msgCardLink struct {
+	AndroidURL string `json:"android_url"`
+	IOSURL     string `json:"ios_url"`
+	PCURL      string `json:"pc_url"`
+	URL        string `json:"url"`
+}
+
+type cardHeaderV2 struct {
+	Icon        *cardIcon     `json:"icon,omitempty"`
+	Subtitle    *cardText     `json:"subtitle,omitempty"`
+	Template    string        `json:"template,omitempty"`
+	TextTagList []cardTextTag `json:"text_tag_list,omitempty"`
+	Title       cardText      `json:"title"`
+
+	err error
+}
+
+type cardIcon struct {
+	Color  string `json:"color,omitempty"`
+	ImgKey string `json:"img_key,omitempty"`
+	Tag    string `json:"tag"`
+	Token  string `json:"token,omitempty"`
+}
+
+type cardTextTag struct {
+	Color string   `json:"color,omitempty"`
+	Tag   string   `json:"tag"`
+	Text  cardText `json:"text"`
+}
+
+// cardText 文本, tag 为 plain_text, lark_md 或 markdown
+type cardText struct {
+	Content     string            `json:"content"`
+	I18nContent map[string]string `json:"i18n_content,omitempty"`
+	Lines       int               `json:"lines,omitempty"`
+	Tag         string            `json:"tag"`
+	TextColor   string            `json:"text_color,omitempty"`
+	TextSize    string            `json:"text_size,omitempty"`
+}
+
+// cardElement 卡片元素
+type cardElement interface {
+	cardTag() string
+}
+
+type cardDiv struct {
+	Extra  interface{}   `json:"extra,omitempty"`
+	Fields []interface{} `json:"fields,omitempty"`
+	Tag    string        `json:"tag"`
+	Text   *cardText     `json:"text,omitempty"`
+
+	extra cardExtra
+}
+
+type cardField struct {
+	IsShort bool        `json:"is_short"`
+	Text    interface{} `json:"text"`
+}
+
+type cardHR struct {
+	Tag string `json:"tag"`
+}
+
+type cardImage struct {
+	Alt          cardText  `json:"alt"`
+	CompactWidth *bool     `json:"compact_width,omitempty"`
+	CustomWidth  int       `json:"custom_width,omitempty"`
+	ImgKey       string    `json:"img_key"`
+	Mode         string    `json:"mode,omitempty"`
+	Preview      *bool     `json:"preview,omitempty"`
+	ScaleType    string    `json:"scale_type,omitempty"`
+	Size         string    `json:"size,omitempty"`
+	Tag          string    `json:"tag"`
+	Title        *cardText `json:"title,omitempty"`
+
+	rawCustomWidth int // 限制范围前的 custom_width, 供 Message.Validate 校验
+	extra          cardExtra
+}
+
+type cardNote struct {
+	Elements []interface{} `json:"elements"`
+	Tag      string        `json:"tag"`
+}
+
+type cardAction struct {
+	Actions []interface{} `json:"actions"`
+	Tag     string        `json:"tag"`
+}
+
+// cardInteractive 交互组件, 包括按钮、下拉选择、人员选择、折叠按钮组、日期选择器、输入框、勾选器
+type cardInteractive struct {
+	ActionType      string         `json:"action_type,omitempty"`
+	Behaviors       []cardBehavior `json:"behaviors,omitempty"`
+	Checked         *bool          `json:"checked,omitempty"`
+	Confirm         *cardConfirm   `json:"confirm,omitempty"`
+	DefaultValue    string         `json:"default_value,omitempty"`
+	FormActionType  string         `json:"form_action_type,omitempty"`
+	InitialDate     string         `json:"initial_date,omitempty"`
+	InitialDatetime string         `json:"initial_datetime,omitempty"`
+	InitialOption   string         `json:"initial_option,omitempty"`
+	InitialTime     string         `json:"initial_time,omitempty"`
+	MaxLength       int            `json:"max_length,omitempty"`
+	MultiURL        *msgCardLink   `json:"multi_url,omitempty"`
+	Name            string         `json:"name,omitempty"`
+	Options         []cardOption   `json:"options,omitempty"`
+	Placeholder     *cardText      `json:"placeholder,omitempty"`
+	Required        *bool          `json:"required,omitempty"`
+	SelectedValues  []string       `json:"selected_values,omitempty"`
+	Tag             string         `json:"tag"`
+	Text            interface{}    `json:"text,omitempty"`
+	Type            string         `json:"type,omitempty"`
+	URL             string         `json:"url,omitempty"`
+	Value           interface{}    `json:"value,omitempty"`
+
+	extra cardExtra
+}
+
+// cardBehavior JSON 2.0 交互组件的交互行为
+type cardBehavior struct {
+	AndroidURL string      `json:"android_url,omitempty"`
+	DefaultURL string      `json:"default_url,omitempty"`
+	IOSURL     string      `json:"ios_url,omitempty"`
+	PCURL      string      `json:"pc_url,omitempty"`
+	Type       string      `json:"type"`
+	Value      interface{} `json:"value,omitempty"`
+}
+
+type cardConfirm struct {
+	Text  cardText `json:"text"`
+	Title cardText `json:"title"`
+}
+
+type cardOption struct {
+	Text  *cardText `json:"text,omitempty"`
+	URL   string    `json:"url,omitempty"`
+	Value string    `json:"value"`
+}
+
+type cardColumnSet struct {
+	BackgroundStyle   string        `json:"background_style,omitempty"`
+	Columns           []interface{} `json:"columns"`
+	FlexMode          string        `json:"flex_mode"`
+	HorizontalSpacing string        `json:"horizontal_spacing,omitempty"`
+	Tag               string        `json:"tag"`
+}
+
+type cardColumn struct {
+	Elements      []interface{} `json:"elements"`
+	Tag           string        `json:"tag"`
+	VerticalAlign string        `json:"vertical_align,omitempty"`
+	Weight        int           `json:"weight,omitempty"`
+	Width         string        `json:"width"`
+}
+
+type cardCollapsiblePanel struct {
+	BackgroundColor string          `json:"background_color,omitempty"`
+	Border          *cardBorder     `json:"border,omitempty"`
+	Elements        []interface{}   `json:"elements"`
+	Expanded        bool            `json:"expanded"`
+	Header          cardPanelHeader `json:"header"`
+	Tag             string          `json:"tag"`
+}
+
+type cardBorder struct {
+	Color        string `json:"color"`
+	CornerRadius string `json:"corner_radius"`
+}
+
+type cardPanelHeader struct {
+	Title cardText `json:"title"`
+}
+
+type cardForm struct {
+	Elements []interface{} `json:"elements"`
+	Name     string        `json:"name"`
+	Tag      string        `json:"tag"`
+}
+
+type cardTable struct {
+	Columns           []cardTableCol           `json:"columns"`
+	FreezeFirstColumn *bool                    `json:"freeze_first_column,omitempty"`
+	PageSize          int                      `json:"page_size"`
+	RowHeight         string                   `json:"row_height,omitempty"`
+	Rows              []map[string]interface{} `json:"rows"`
+	Tag               string                   `json:"tag"`
+}
+
+type cardTableCol struct {
+	DataType        string              `json:"data_type"`
+	DateFormat      string              `json:"date_format,omitempty"`
+	DisplayName     string              `json:"display_name,omitempty"`
+	Format          *cardTableNumberFmt `json:"format,omitempty"`
+	HorizontalAlign string              `json:"horizontal_align,omitempty"`
+	Name            string              `json:"name"`
+	Width           string              `json:"width,omitempty"`
+}
+
+type cardTableNumberFmt struct {
+	Precision *int   `json:"precision,omitempty"`
+	Separator bool   `json:"separator,omitempty"`
+	Symbol    string `json:"symbol,omitempty"`
+}
+
+type cardChart struct {
+	AspectRatio string      `json:"aspect_ratio,omitempty"`
+	ChartSpec   interface{} `json:"chart_spec"`
+	Preview     *bool       `json:"preview,omitempty"`
+	Tag         string      `json:"tag"`
+}
+
+// cardChartSpec 由 WithCardElementChart 生成的 VChart 图表定义
+type cardChartSpec struct {
+	CategoryField string            `json:"categoryField,omitempty"`
+	Data          []cardChartData   `json:"data"`
+	Legends       *cardChartLegends `json:"legends,omitempty"`
+	SeriesField   string            `json:"seriesField,omitempty"`
+	Title         *cardChartTitle   `json:"title,omitempty"`
+	Type          string            `json:"type"`
+	ValueField    string            `json:"valueField,omitempty"`
+	XField        interface{}       `json:"xField,omitempty"`
+	YField        string            `json:"yField,omitempty"`
+}
+
+type cardChartData struct {
+	Values interface{} `json:"values"` // []cardChartPoint 或 []cardChartSlice
+}
+
+type cardChartPoint struct {
+	Series string  `json:"series"`
+	X      string  `json:"x"`
+	Y      float64 `json:"y"`
+}
+
+type cardChartSlice struct {
+	Category string  `json:"category"`
+	Value    float64 `json:"value"`
+}
+
+type cardChartLegends struct {
+	Visible bool `json:"visible"`
+}
+
+type cardChartTitle struct {
+	Text string `json:"text"`
+}
+
+type cardPerson struct {
+	Persons    []cardPersonID `json:"persons,omitempty"`
+	ShowAvatar *bool          `json:"show_avatar,omitempty"`
+	ShowName   *bool          `json:"show_name,omitempty"`
+	Size       string         `json:"size,omitempty"`
+	Tag        string         `json:"tag"`
+	UserID     string         `json:"user_id,omitempty"`
+}
+
+type cardPersonID struct {
+	ID string `json:"id"`
+}
+
+func (e cardDiv) cardTag() string              { return e.Tag }
+func (e cardHR) cardTag() string               { return e.Tag }
+func (e cardImage) cardTag() string            { return e.Tag }
+func (e cardNote) cardTag() string             { return e.Tag }
+func (e cardAction) cardTag() string           { return e.Tag }
+func (e cardInteractive) cardTag() string      { return e.Tag }
+func (e cardColumnSet) cardTag() string        { return e.Tag }
+func (e cardColumn) cardTag() string           { return e.Tag }
+func (e cardCollapsiblePanel) cardTag() string { return e.Tag }
+func (e cardForm) cardTag() string             { return e.Tag }
+func (e cardTable) cardTag() string            { return e.Tag }
+func (e cardChart) cardTag() string            { return e.Tag }
+func (e cardPerson) cardTag() string           { return e.Tag }
+
+// walkCardElement 深度优先遍历卡片元素及其子元素
+// cardExtra 公开的 option 类型(如 CardElementActionOption)返回的、没有对应字段的属性
+//  序列化时合并到元素中, 同名属性以 cardExtra 为准
+type cardExtra map[string]interface{}
+
+func (extra *cardExtra) set(key string, v interface{}) {
+	if *extra == nil {
+		*extra = make(cardExtra)
+	}
+	(*extra)[key] = v
+}
+
+func marshalCardExtra(v interface{}, extra cardExtra) ([]byte, error) {
+	bs, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return bs, err
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(bs, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+func (e *cardDiv) MarshalJSON() ([]byte, error) {
+	type alias cardDiv
+	return marshalCardExtra((*alias)(e), e.extra)
+}
+
+func (e *cardDiv) set(key string, v interface{}) {
+	if key == "extra" {
+		e.Extra = v
+		return
+	}
+	e.extra.set(key, v)
+}
+
+func (e *cardImage) MarshalJSON() ([]byte, error) {
+	type alias cardImage
+	return marshalCardExtra((*alias)(e), e.extra)
+}
+
+func (e *cardImage) set(key string, v interface{}) {
+	switch key {
+	case "alt":
+		if t, ok := v.(cardText); ok {
+			e.Alt = t
+			return
+		}
+	case "title":
+		if t, ok := v.(*cardText); ok {
+			e.Title = t
+			return
+		}
+	case "mode":
+		if s, ok := v.(string); ok {
+			e.Mode = s
+			return
+		}
+	case "custom_width":
+		if w, ok := v.(int); ok {
+			e.rawCustomWidth, e.CustomWidth = w, w
+			if w < _cardImageMinWidth {
+				e.CustomWidth = _cardImageMinWidth
+			}
+			if w > _cardImageMaxWidth {
+				e.CustomWidth = _cardImageMaxWidth
+			}
+			return
+		}
+	case "compact_width":
+		if b, ok := v.(bool); ok {
+			e.CompactWidth = &b
+			return
+		}
+	case "preview":
+		if b, ok := v.(bool); ok {
+			e.Preview = &b
+			return
+		}
+	}
+	e.extra.set(key, v)
+}
+
+func (e *cardInteractive) MarshalJSON() ([]byte, error) {
+	type alias cardInteractive
+	return marshalCardExtra((*alias)(e), e.extra)
+}
+
+func (e *cardInteractive) set(key string, v interface{}) {
+	switch x := v.(type) {
+	case string:
+		if p := e.stringField(key); p != nil {
+			*p = x
+			return
+		}
+	case bool:
+		switch key {
+		case "checked":
+			e.Checked = &x
+			return
+		case "required":
+			e.Required = &x
+			return
+		}
+	case int:
+		if key == "max_length" {
+			e.MaxLength = x
+			return
+		}
+	case []string:
+		if key == "selected_values" {
+			e.SelectedValues = x
+			return
+		}
+	case *cardText:
+		if key == "placeholder" {
+			e.Placeholder = x
+			return
+		}
+	case *cardConfirm:
+		if key == "confirm" {
+			e.Confirm = x
+			return
+		}
+	case *msgCardLink:
+		if key == "multi_url" {
+			e.MultiURL = x
+			return
+		}
+	}
+	switch key {
+	case "text":
+		e.Text = v
+	case "value":
+		e.Value = v
+	default:
+		e.extra.set(key, v)
+	}
+}
+
+func (e *cardInteractive) stringField(key string) *string {
+	switch key {
+	case "action_type":
+		return &e.ActionType
+	case "default_value":
+		return &e.DefaultValue
+	case "form_action_type":
+		return &e.FormActionType
+	case "initial_date":
+		return &e.InitialDate
+	case "initial_datetime":
+		return &e.InitialDatetime
+	case "initial_option":
+		return &e.InitialOption
+	case "initial_time":
+		return &e.InitialTime
+	case "name":
+		return &e.Name
+	case "type":
+		return &e.Type
+	case "url":
+		return &e.URL
+	}
+	return nil
+}
+
+func walkCardElement(v interface{}, fn func(v interface{}) error) error {
+	if err := fn(v); err != nil {
+		return err
+	}
+	var children []interface{}
+	switch e := v.(type) {
+	case *cardDiv:
+		if e.Extra != nil {
+			children = []interface{}{e.Extra}
+		}
+	case *cardNote:
+		children = e.Elements
+	case *cardAction:
+		children = e.Actions
+	case *cardColumnSet:
+		children = e.Columns
+	case *cardColumn:
+		children = e.Elements
+	case *cardCollapsiblePanel:
+		children = e.Elements
+	case *cardForm:
+		children = e.Elements
+	}
+	for _, sub := range children {
+		if err := walkCardElement(sub, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ⬆️ ---------------------------------------- 消息卡片 interactive ---------------------------------------- ⬆️
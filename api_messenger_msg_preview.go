@@ -0,0 +1,996 @@
+package feishu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type previewOption struct {
+	lang Language
+}
+
+type PreviewOption func(*previewOption)
+
+// WithPreviewLanguage 预览的语言环境
+//  默认为 zh_cn, 消息不包含该语言环境时使用第一个语言环境（按字典序, JSON 2.0 卡片为未指定语言环境的 content）
+//  PreviewHTML 包含所有语言环境并可切换, 此选项指定默认展示的语言环境
+func WithPreviewLanguage(lang Language) PreviewOption {
+	return func(opt *previewOption) {
+		opt.lang = lang
+	}
+}
+
+// PreviewHTML 将消息渲染为独立的 HTML 页面, 用于在本地查看消息的大致效果
+//  支持文本、富文本及消息卡片（JSON 1.0、JSON 2.0）, 其他类型的消息仅展示消息内容的摘要
+//  样式近似飞书客户端, 图片等资源仅展示 key, 交互组件不可操作
+//  多语言消息可在页面顶部切换语言环境
+//  输出是确定的, 可用于快照测试
+func (msg *Message) PreviewHTML(opts ...PreviewOption) (string, error) {
+	doc, err := parsePreview(msg, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(doc.title(doc.lang)))
+	b.WriteString("<style>\n")
+	b.WriteString(_previewCSS)
+	for i := range doc.langs {
+		fmt.Fprintf(&b, "#lang-%d:checked ~ .lang-%d { display: block; }\n", i, i)
+	}
+	b.WriteString("</style>\n</head>\n<body>\n")
+	for i, lang := range doc.langs {
+		checked := ""
+		if lang == doc.lang {
+			checked = " checked"
+		}
+		fmt.Fprintf(&b, "<input type=\"radio\" name=\"lang\" id=\"lang-%d\"%s>\n", i, checked)
+	}
+	if len(doc.langs) > 1 {
+		b.WriteString("<nav class=\"langs\">")
+		for i, lang := range doc.langs {
+			if lang == "" {
+				lang = "default"
+			}
+			fmt.Fprintf(&b, "<label for=\"lang-%d\">%s</label>", i, html.EscapeString(lang))
+		}
+		b.WriteString("</nav>\n")
+	}
+	for i, lang := range doc.langs {
+		fmt.Fprintf(&b, "<section class=\"lang lang-%d msg msg-%s\">\n", i, html.EscapeString(doc.msgType))
+		p := &previewer{lang: lang, b: &b}
+		p.htmlMessage(doc)
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String(), nil
+}
+
+// PreviewText 将消息渲染为纯文本, 用于日志、代码评审及快照测试
+//  仅渲染一个语言环境, 参见 WithPreviewLanguage
+//  按钮、下拉选择等交互组件渲染为 [文本] 的形式, 图片渲染为 [image: key]
+func (msg *Message) PreviewText(opts ...PreviewOption) (string, error) {
+	doc, err := parsePreview(msg, opts)
+	if err != nil {
+		return "", err
+	}
+	p := &previewer{lang: doc.lang}
+	lines := p.textMessage(doc)
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// previewDoc 预览的消息内容, 由序列化后的 JSON 解析而来, 因此同样支持 NewMessageFromContent 构造的消息
+type previewDoc struct {
+	msgType string
+	content map[string]interface{}
+	langs   []string // 消息包含的语言环境, "" 表示不区分语言环境
+	lang    string   // 默认展示的语言环境
+}
+
+func parsePreview(msg *Message, opts []PreviewOption) (*previewDoc, error) {
+	opt := previewOption{lang: LangChinese}
+	for _, fn := range opts {
+		fn(&opt)
+	}
+
+	content, err := msg.marshalContent()
+	if err != nil {
+		return nil, err
+	}
+	doc := &previewDoc{msgType: msg.msgType}
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	if err = dec.Decode(&doc.content); err != nil {
+		return nil, fmt.Errorf("preview: unsupported content: %w", err)
+	}
+
+	langs := make(map[string]bool)
+	switch msg.msgType {
+	case "post":
+		if _, ok := doc.content["content"]; !ok {
+			for lang := range doc.content {
+				langs[lang] = true
+			}
+		}
+	case "interactive":
+		if i18n := pvMap(doc.content, "i18n_elements"); i18n != nil {
+			for lang := range i18n {
+				langs[lang] = true
+			}
+		} else if collectPreviewLangs(doc.content, langs); len(langs) != 0 {
+			// JSON 2.0 卡片未指定语言环境的文本使用 content
+			langs[""] = true
+		}
+	}
+	doc.langs = make([]string, 0, len(langs))
+	for lang := range langs {
+		doc.langs = append(doc.langs, lang)
+	}
+	sort.Strings(doc.langs)
+	if len(doc.langs) == 0 {
+		doc.langs = []string{""}
+	}
+
+	doc.lang = doc.langs[0]
+	if langs[string(opt.lang)] {
+		doc.lang = string(opt.lang)
+	}
+	return doc, nil
+}
+
+// collectPreviewLangs 收集 JSON 2.0 卡片中 i18n_content 的语言环境
+func collectPreviewLangs(v interface{}, langs map[string]bool) {
+	switch e := v.(type) {
+	case map[string]interface{}:
+		for key, sub := range e {
+			if m, ok := sub.(map[string]interface{}); ok && key == "i18n_content" {
+				for lang := range m {
+					langs[lang] = true
+				}
+				continue
+			}
+			collectPreviewLangs(sub, langs)
+		}
+	case []interface{}:
+		for _, sub := range e {
+			collectPreviewLangs(sub, langs)
+		}
+	}
+}
+
+// title 消息的标题, 用于 HTML 页面的标题
+func (doc *previewDoc) title(lang string) string {
+	p := &previewer{lang: lang}
+	switch doc.msgType {
+	case "post":
+		return pvStr(p.postBody(doc.content), "title")
+	case "interactive":
+		if title := p.cardTitle(doc.content); title != "" {
+			return title
+		}
+	}
+	return doc.msgType
+}
+
+type previewer struct {
+	lang string
+	b    *strings.Builder
+}
+
+func (p *previewer) printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.b, format, args...)
+}
+
+// text 文本对象在当前语言环境的内容, 优先使用 i18n_content
+func (p *previewer) text(t map[string]interface{}) string {
+	if s := pvStr(pvMap(t, "i18n_content"), p.lang); s != "" {
+		return s
+	}
+	return pvStr(t, "content")
+}
+
+func (p *previewer) postBody(content map[string]interface{}) map[string]interface{} {
+	if _, ok := content["content"]; ok {
+		return content
+	}
+	return pvMap(content, p.lang)
+}
+
+func (p *previewer) cardTitle(card map[string]interface{}) string {
+	title := pvMap(pvMap(card, "header"), "title")
+	if s := pvStr(pvMap(title, "i18n"), p.lang); s != "" {
+		return s
+	}
+	return p.text(title)
+}
+
+func (p *previewer) cardElements(card map[string]interface{}) []interface{} {
+	if i18n := pvMap(card, "i18n_elements"); i18n != nil {
+		return pvList(i18n, p.lang)
+	}
+	if body := pvMap(card, "body"); body != nil {
+		return pvList(body, "elements")
+	}
+	return pvList(card, "elements")
+}
+
+// ⬇️ ---------------------------------------- HTML ---------------------------------------- ⬇️
+
+const _previewCSS = `body { margin: 0; padding: 24px; background: #f5f6f7; color: #1f2329; font: 14px/1.5 -apple-system, BlinkMacSystemFont, "PingFang SC", "Helvetica Neue", Arial, sans-serif; }
+input[name=lang], .lang { display: none; }
+.langs { margin-bottom: 12px; }
+.langs label { display: inline-block; margin-right: 4px; padding: 2px 10px; border-radius: 4px; background: #e1e2e3; cursor: pointer; }
+.msg { max-width: 600px; overflow: hidden; border: 1px solid #dee0e3; border-radius: 8px; background: #fff; }
+.content { padding: 12px 16px; }
+.card-body { display: flex; flex-direction: column; gap: 12px; }
+.card-header { padding: 12px 16px; font-size: 16px; font-weight: 600; border-bottom: 1px solid #dee0e3; }
+.card-header.colored { border-bottom: none; color: #fff; }
+.card-header .subtitle { font-size: 14px; font-weight: 400; opacity: .8; }
+.card-header .text-tag { margin-left: 8px; padding: 0 6px; border-radius: 4px; background: rgba(255, 255, 255, .3); font-size: 12px; font-weight: 400; }
+h3 { margin: 0 0 8px; font-size: 16px; }
+p { margin: 0 0 4px; }
+a, .at { color: #3370ff; text-decoration: none; }
+code { padding: 0 4px; border-radius: 4px; background: #eff0f1; font-family: Menlo, Consolas, monospace; }
+pre { margin: 4px 0; padding: 8px 12px; overflow: auto; border-radius: 6px; background: #f5f6f7; }
+hr { width: 100%; margin: 0; border: none; border-top: 1px solid #dee0e3; }
+.notation { color: #8f959e; font-size: 12px; }
+.fields { display: flex; flex-wrap: wrap; row-gap: 8px; }
+.field { width: 100%; }
+.field.short { width: 50%; }
+.img { padding: 32px 8px; border-radius: 6px; background: #eff0f1; color: #8f959e; font-size: 12px; text-align: center; word-break: break-all; }
+.img.small { padding: 2px 6px; }
+.note { display: flex; flex-wrap: wrap; align-items: center; gap: 6px; color: #8f959e; font-size: 12px; }
+.actions { display: flex; flex-wrap: wrap; gap: 8px; }
+.btn, .select, .input { display: inline-block; padding: 4px 12px; border: 1px solid #d0d3d6; border-radius: 6px; background: #fff; }
+.card-body > span, .form > span { align-self: flex-start; }
+.btn-primary { border-color: #3370ff; background: #3370ff; color: #fff; }
+.btn-danger { border-color: #f54a45; color: #f54a45; }
+.select, .input { color: #8f959e; }
+.input { display: block; }
+.columns { display: flex; gap: 8px; }
+.columns.flow { flex-wrap: wrap; }
+.columns.grey { padding: 8px; border-radius: 6px; background: #f2f3f5; }
+.column { display: flex; flex: 1; flex-direction: column; gap: 8px; min-width: 0; }
+.column.auto { flex: 0 0 auto; }
+.panel { padding: 8px 12px; border: 1px solid #dee0e3; border-radius: 6px; }
+.panel summary { cursor: pointer; font-weight: 600; }
+.panel > div { display: flex; flex-direction: column; gap: 8px; margin-top: 8px; }
+.form { display: flex; flex-direction: column; gap: 12px; }
+table { width: 100%; border-collapse: collapse; }
+th, td { padding: 6px 8px; border: 1px solid #dee0e3; text-align: left; }
+th { background: #f5f6f7; }
+.chart { padding: 48px 8px; border: 1px dashed #d0d3d6; border-radius: 6px; color: #8f959e; text-align: center; }
+`
+
+// _previewHeaderColors 卡片标题的背景色
+var _previewHeaderColors = map[string]string{
+	"blue": "#3370ff", "wathet": "#50a3fa", "turquoise": "#2ec8b4", "green": "#34c724",
+	"yellow": "#ffc60a", "orange": "#ff8800", "red": "#f54a45", "carmine": "#e8336e",
+	"violet": "#d136d1", "purple": "#7f3bf5", "indigo": "#4954e6", "grey": "#8f959e",
+}
+
+func (p *previewer) htmlMessage(doc *previewDoc) {
+	switch doc.msgType {
+	case "text":
+		p.printf("<div class=\"content\">%s</div>\n", previewPlain(pvStr(doc.content, "text"), true))
+	case "post":
+		p.htmlPost(p.postBody(doc.content))
+	case "interactive":
+		if pvStr(doc.content, "type") == "template" {
+			p.printf("<div class=\"content\">%s</div>\n", html.EscapeString(previewSummary(doc)))
+			return
+		}
+		p.htmlCard(doc.content)
+	default:
+		p.printf("<div class=\"content\">%s</div>\n", html.EscapeString(previewSummary(doc)))
+	}
+}
+
+func (p *previewer) htmlPost(body map[string]interface{}) {
+	p.b.WriteString("<div class=\"content\">\n")
+	if title := pvStr(body, "title"); title != "" {
+		p.printf("<h3>%s</h3>\n", html.EscapeString(title))
+	}
+	for _, para := range pvList(body, "content") {
+		elements, _ := para.([]interface{})
+		var line strings.Builder
+		flush := func() {
+			if line.Len() != 0 {
+				p.printf("<p>%s</p>\n", line.String())
+				line.Reset()
+			}
+		}
+		for _, elem := range elements {
+			e := pvMapOf(elem)
+			switch pvStr(e, "tag") {
+			case "text":
+				line.WriteString(previewStyled(html.EscapeString(pvStr(e, "text")), pvList(e, "style")))
+			case "a":
+				line.WriteString(previewStyled(previewLink(pvStr(e, "href"), pvStr(e, "text"), true), pvList(e, "style")))
+			case "at":
+				line.WriteString(previewMention(pvStr(e, "user_id"), pvStr(e, "user_name"), true))
+			case "img":
+				flush()
+				p.printf("<div class=\"img\">image: %s</div>\n", html.EscapeString(pvStr(e, "image_key")))
+			case "media":
+				flush()
+				p.printf("<div class=\"img\">▶ media: %s</div>\n", html.EscapeString(pvStr(e, "file_key")))
+			case "emotion":
+				line.WriteString("[" + html.EscapeString(pvStr(e, "emoji_type")) + "]")
+			case "code_block":
+				flush()
+				p.printf("<pre><code>%s</code></pre>\n", html.EscapeString(pvStr(e, "text")))
+			case "hr":
+				flush()
+				p.b.WriteString("<hr>\n")
+			case "md":
+				line.WriteString(previewMarkdown(pvStr(e, "text"), true))
+			}
+		}
+		flush()
+	}
+	p.b.WriteString("</div>\n")
+}
+
+func (p *previewer) htmlCard(card map[string]interface{}) {
+	if header := pvMap(card, "header"); header != nil {
+		if color, ok := _previewHeaderColors[pvStr(header, "template")]; ok {
+			p.printf("<div class=\"card-header colored\" style=\"background: %s\">", color)
+		} else {
+			p.b.WriteString("<div class=\"card-header\">")
+		}
+		p.b.WriteString(html.EscapeString(p.cardTitle(card)))
+		for _, tag := range pvList(header, "text_tag_list") {
+			p.printf("<span class=\"text-tag\">%s</span>", html.EscapeString(p.text(pvMap(pvMapOf(tag), "text"))))
+		}
+		if subtitle := pvMap(header, "subtitle"); subtitle != nil {
+			p.printf("<div class=\"subtitle\">%s</div>", html.EscapeString(p.text(subtitle)))
+		}
+		p.b.WriteString("</div>\n")
+	}
+	p.b.WriteString("<div class=\"content card-body\">\n")
+	p.htmlElements(p.cardElements(card))
+	p.b.WriteString("</div>\n")
+}
+
+func (p *previewer) htmlElements(elements []interface{}) {
+	for _, elem := range elements {
+		p.htmlElement(pvMapOf(elem))
+	}
+}
+
+func (p *previewer) htmlElement(e map[string]interface{}) {
+	switch tag := pvStr(e, "tag"); tag {
+	case "div":
+		p.b.WriteString("<div>")
+		if text := pvMap(e, "text"); text != nil {
+			p.b.WriteString(p.htmlText(text))
+		}
+		if fields := pvList(e, "fields"); len(fields) != 0 {
+			p.b.WriteString("<div class=\"fields\">")
+			for _, f := range fields {
+				field := pvMapOf(f)
+				class := "field"
+				if b, _ := field["is_short"].(bool); b {
+					class += " short"
+				}
+				p.printf("<div class=\"%s\">%s</div>", class, p.htmlText(pvMap(field, "text")))
+			}
+			p.b.WriteString("</div>")
+		}
+		if extra := pvMap(e, "extra"); extra != nil {
+			p.htmlElement(extra)
+		}
+		p.b.WriteString("</div>\n")
+	case "markdown":
+		p.printf("<div>%s</div>\n", previewMarkdown(p.text(e), true))
+	case "hr":
+		p.b.WriteString("<hr>\n")
+	case "img":
+		p.printf("<div class=\"img\">%s</div>\n", html.EscapeString(p.imageLabel(e)))
+	case "note":
+		p.b.WriteString("<div class=\"note\">")
+		for _, sub := range pvList(e, "elements") {
+			n := pvMapOf(sub)
+			if pvStr(n, "tag") == "img" {
+				p.printf("<span class=\"img small\">%s</span>", html.EscapeString(pvStr(n, "img_key")))
+				continue
+			}
+			p.printf("<span>%s</span>", p.htmlText(n))
+		}
+		p.b.WriteString("</div>\n")
+	case "action":
+		p.b.WriteString("<div class=\"actions\">")
+		for _, sub := range pvList(e, "actions") {
+			p.htmlElement(pvMapOf(sub))
+		}
+		p.b.WriteString("</div>\n")
+	case "button":
+		class := "btn"
+		if typ := pvStr(e, "type"); typ == "primary" || typ == "danger" {
+			class += " btn-" + typ
+		}
+		p.printf("<span class=\"%s\">%s</span>", class, html.EscapeString(p.text(pvMap(e, "text"))))
+	case "input":
+		p.printf("<div class=\"input\">%s</div>\n", html.EscapeString(p.interactiveLabel(e)))
+	case "checker":
+		box := "☐"
+		if b, _ := e["checked"].(bool); b {
+			box = "☑"
+		}
+		p.printf("<div>%s %s</div>\n", box, p.htmlText(pvMap(e, "text")))
+	case "select_static", "multi_select_static", "select_person", "overflow", "date_picker", "picker_time", "picker_datetime":
+		p.printf("<span class=\"select\">%s</span>", html.EscapeString(p.interactiveLabel(e)))
+	case "column_set":
+		class := "columns"
+		if pvStr(e, "flex_mode") == "flow" {
+			class += " flow"
+		}
+		if pvStr(e, "background_style") == "grey" {
+			class += " grey"
+		}
+		p.printf("<div class=\"%s\">\n", class)
+		for _, sub := range pvList(e, "columns") {
+			col := pvMapOf(sub)
+			switch width := pvStr(col, "width"); {
+			case width == "auto":
+				p.b.WriteString("<div class=\"column auto\">\n")
+			case strings.HasSuffix(width, "px"):
+				p.printf("<div class=\"column\" style=\"flex: 0 0 %s\">\n", html.EscapeString(width))
+			case col["weight"] != nil:
+				p.printf("<div class=\"column\" style=\"flex: %s\">\n", html.EscapeString(pvStr(col, "weight")))
+			default:
+				p.b.WriteString("<div class=\"column\">\n")
+			}
+			p.htmlElements(pvList(col, "elements"))
+			p.b.WriteString("</div>\n")
+		}
+		p.b.WriteString("</div>\n")
+	case "collapsible_panel":
+		open := ""
+		if b, _ := e["expanded"].(bool); b {
+			open = " open"
+		}
+		p.printf("<details class=\"panel\"%s><summary>%s</summary><div>\n", open,
+			html.EscapeString(p.text(pvMap(pvMap(e, "header"), "title"))))
+		p.htmlElements(pvList(e, "elements"))
+		p.b.WriteString("</div></details>\n")
+	case "form":
+		p.b.WriteString("<div class=\"form\">\n")
+		p.htmlElements(pvList(e, "elements"))
+		p.b.WriteString("</div>\n")
+	case "table":
+		cols := pvList(e, "columns")
+		p.b.WriteString("<table>\n<tr>")
+		for _, c := range cols {
+			col := pvMapOf(c)
+			name := pvStr(col, "display_name")
+			if name == "" {
+				name = pvStr(col, "name")
+			}
+			p.printf("<th>%s</th>", html.EscapeString(name))
+		}
+		p.b.WriteString("</tr>\n")
+		for _, r := range pvList(e, "rows") {
+			row := pvMapOf(r)
+			p.b.WriteString("<tr>")
+			for _, c := range cols {
+				p.printf("<td>%s</td>", html.EscapeString(previewCell(row[pvStr(pvMapOf(c), "name")])))
+			}
+			p.b.WriteString("</tr>\n")
+		}
+		p.b.WriteString("</table>\n")
+	case "chart":
+		p.printf("<div class=\"chart\">%s</div>\n", html.EscapeString(previewChartLabel(e)))
+	case "person", "avatar", "person_list":
+		p.printf("<div>%s</div>\n", html.EscapeString(previewPersons(e)))
+	}
+}
+
+// htmlText 文本对象, lark_md 及 markdown 按 Markdown 渲染
+func (p *previewer) htmlText(t map[string]interface{}) string {
+	s := p.text(t)
+	var out string
+	switch pvStr(t, "tag") {
+	case "lark_md", "markdown":
+		out = previewMarkdown(s, true)
+	default:
+		out = strings.ReplaceAll(html.EscapeString(s), "\n", "<br>")
+	}
+	if pvStr(t, "text_size") == "notation" {
+		out = "<span class=\"notation\">" + out + "</span>"
+	}
+	return out
+}
+
+// ⬆️ ---------------------------------------- HTML ---------------------------------------- ⬆️
+
+// ⬇️ ---------------------------------------- 纯文本 ---------------------------------------- ⬇️
+
+func (p *previewer) textMessage(doc *previewDoc) []string {
+	switch doc.msgType {
+	case "text":
+		return []string{previewPlain(pvStr(doc.content, "text"), false)}
+	case "post":
+		return p.textPost(p.postBody(doc.content))
+	case "interactive":
+		if pvStr(doc.content, "type") == "template" {
+			return []string{previewSummary(doc)}
+		}
+		return p.textCard(doc.content)
+	}
+	return []string{previewSummary(doc)}
+}
+
+func (p *previewer) textPost(body map[string]interface{}) []string {
+	var lines []string
+	if title := pvStr(body, "title"); title != "" {
+		lines = append(lines, title, "")
+	}
+	for _, para := range pvList(body, "content") {
+		elements, _ := para.([]interface{})
+		var line strings.Builder
+		flush := func() {
+			if line.Len() != 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+			}
+		}
+		for _, elem := range elements {
+			e := pvMapOf(elem)
+			switch pvStr(e, "tag") {
+			case "text":
+				line.WriteString(pvStr(e, "text"))
+			case "a":
+				line.WriteString(previewLink(pvStr(e, "href"), pvStr(e, "text"), false))
+			case "at":
+				line.WriteString(previewMention(pvStr(e, "user_id"), pvStr(e, "user_name"), false))
+			case "img":
+				flush()
+				lines = append(lines, "[image: "+pvStr(e, "image_key")+"]")
+			case "media":
+				flush()
+				lines = append(lines, "[media: "+pvStr(e, "file_key")+"]")
+			case "emotion":
+				line.WriteString("[" + pvStr(e, "emoji_type") + "]")
+			case "code_block":
+				flush()
+				lines = append(lines, "```"+pvStr(e, "language"), strings.TrimSuffix(pvStr(e, "text"), "\n"), "```")
+			case "hr":
+				flush()
+				lines = append(lines, "---")
+			case "md":
+				line.WriteString(previewMarkdown(pvStr(e, "text"), false))
+			}
+		}
+		flush()
+	}
+	return lines
+}
+
+func (p *previewer) textCard(card map[string]interface{}) []string {
+	var lines []string
+	if header := pvMap(card, "header"); header != nil {
+		title := "# " + p.cardTitle(card)
+		for _, tag := range pvList(header, "text_tag_list") {
+			title += " [" + p.text(pvMap(pvMapOf(tag), "text")) + "]"
+		}
+		lines = append(lines, title)
+		if subtitle := pvMap(header, "subtitle"); subtitle != nil {
+			lines = append(lines, p.text(subtitle))
+		}
+		lines = append(lines, "")
+	}
+	return append(lines, p.textElements(p.cardElements(card))...)
+}
+
+func (p *previewer) textElements(elements []interface{}) []string {
+	var lines []string
+	for _, elem := range elements {
+		lines = append(lines, p.textElement(pvMapOf(elem))...)
+	}
+	return lines
+}
+
+func (p *previewer) textElement(e map[string]interface{}) []string {
+	switch tag := pvStr(e, "tag"); tag {
+	case "div":
+		var lines []string
+		if text := pvMap(e, "text"); text != nil {
+			lines = append(lines, p.textText(text))
+		}
+		for _, f := range pvList(e, "fields") {
+			lines = append(lines, p.textText(pvMap(pvMapOf(f), "text")))
+		}
+		if extra := pvMap(e, "extra"); extra != nil {
+			lines = append(lines, p.textElement(extra)...)
+		}
+		return lines
+	case "markdown":
+		return []string{previewMarkdown(p.text(e), false)}
+	case "hr":
+		return []string{"---"}
+	case "img":
+		return []string{"[image: " + p.imageLabel(e) + "]"}
+	case "note":
+		parts := make([]string, 0, len(pvList(e, "elements")))
+		for _, sub := range pvList(e, "elements") {
+			n := pvMapOf(sub)
+			if pvStr(n, "tag") == "img" {
+				parts = append(parts, "[image: "+pvStr(n, "img_key")+"]")
+				continue
+			}
+			parts = append(parts, p.textText(n))
+		}
+		return []string{"> " + strings.Join(parts, " ")}
+	case "action":
+		parts := make([]string, 0, len(pvList(e, "actions")))
+		for _, sub := range pvList(e, "actions") {
+			parts = append(parts, p.textElement(pvMapOf(sub))...)
+		}
+		return []string{strings.Join(parts, " ")}
+	case "button":
+		return []string{"[" + p.text(pvMap(e, "text")) + "]"}
+	case "checker":
+		box := "[ ]"
+		if b, _ := e["checked"].(bool); b {
+			box = "[x]"
+		}
+		return []string{box + " " + p.textText(pvMap(e, "text"))}
+	case "input", "select_static", "multi_select_static", "select_person", "overflow", "date_picker", "picker_time", "picker_datetime":
+		return []string{"[" + p.interactiveLabel(e) + "]"}
+	case "column_set":
+		var lines []string
+		columns := pvList(e, "columns")
+		// 流式排布的多列布局（如 JSON 2.0 的交互模块）在一行内展示
+		if pvStr(e, "flex_mode") == "flow" {
+			parts := make([]string, 0, len(columns))
+			for _, sub := range columns {
+				parts = append(parts, p.textElements(pvList(pvMapOf(sub), "elements"))...)
+			}
+			return []string{strings.Join(parts, " ")}
+		}
+		for _, sub := range columns {
+			lines = append(lines, p.textElements(pvList(pvMapOf(sub), "elements"))...)
+		}
+		return lines
+	case "collapsible_panel":
+		mark := "▶"
+		if b, _ := e["expanded"].(bool); b {
+			mark = "▼"
+		}
+		lines := []string{mark + " " + p.text(pvMap(pvMap(e, "header"), "title"))}
+		for _, line := range p.textElements(pvList(e, "elements")) {
+			lines = append(lines, "  "+line)
+		}
+		return lines
+	case "form":
+		return p.textElements(pvList(e, "elements"))
+	case "table":
+		cols := pvList(e, "columns")
+		header := make([]string, 0, len(cols))
+		for _, c := range cols {
+			name := pvStr(pvMapOf(c), "display_name")
+			if name == "" {
+				name = pvStr(pvMapOf(c), "name")
+			}
+			header = append(header, name)
+		}
+		lines := []string{"| " + strings.Join(header, " | ") + " |"}
+		for _, r := range pvList(e, "rows") {
+			row := pvMapOf(r)
+			cells := make([]string, 0, len(cols))
+			for _, c := range cols {
+				cells = append(cells, previewCell(row[pvStr(pvMapOf(c), "name")]))
+			}
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		}
+		return lines
+	case "chart":
+		return []string{"[" + previewChartLabel(e) + "]"}
+	case "person", "avatar", "person_list":
+		return []string{previewPersons(e)}
+	}
+	return nil
+}
+
+func (p *previewer) textText(t map[string]interface{}) string {
+	switch pvStr(t, "tag") {
+	case "lark_md", "markdown":
+		return previewMarkdown(p.text(t), false)
+	}
+	return p.text(t)
+}
+
+// ⬆️ ---------------------------------------- 纯文本 ---------------------------------------- ⬆️
+
+func (p *previewer) imageLabel(e map[string]interface{}) string {
+	label := pvStr(e, "img_key")
+	if title := p.text(pvMap(e, "title")); title != "" {
+		label = title + " (" + label + ")"
+	}
+	return label
+}
+
+// interactiveLabel 交互组件的展示文本, 依次使用选中的值、占位文本及组件类型
+func (p *previewer) interactiveLabel(e map[string]interface{}) string {
+	tag := pvStr(e, "tag")
+	options := make(map[string]string)
+	for _, o := range pvList(e, "options") {
+		opt := pvMapOf(o)
+		options[pvStr(opt, "value")] = p.text(pvMap(opt, "text"))
+	}
+	optionText := func(value string) string {
+		if s := options[value]; s != "" {
+			return s
+		}
+		return value
+	}
+
+	var label string
+	switch {
+	case tag == "overflow":
+		return "⋯"
+	case pvStr(e, "initial_option") != "":
+		label = optionText(pvStr(e, "initial_option"))
+	case len(pvList(e, "selected_values")) != 0:
+		values := make([]string, 0, len(pvList(e, "selected_values")))
+		for _, v := range pvList(e, "selected_values") {
+			values = append(values, optionText(previewCell(v)))
+		}
+		label = strings.Join(values, ", ")
+	case pvStr(e, "initial_date") != "":
+		label = pvStr(e, "initial_date")
+	case pvStr(e, "initial_time") != "":
+		label = pvStr(e, "initial_time")
+	case pvStr(e, "initial_datetime") != "":
+		label = pvStr(e, "initial_datetime")
+	case pvStr(e, "default_value") != "":
+		label = pvStr(e, "default_value")
+	case pvMap(e, "placeholder") != nil:
+		label = p.text(pvMap(e, "placeholder"))
+	default:
+		label = tag
+	}
+	if tag != "input" {
+		label += " ▾"
+	}
+	return label
+}
+
+func previewChartLabel(e map[string]interface{}) string {
+	label := "chart"
+	spec := pvMap(e, "chart_spec")
+	if typ := pvStr(spec, "type"); typ != "" {
+		label += ": " + typ
+	}
+	if title := pvStr(pvMap(spec, "title"), "text"); title != "" {
+		label += " " + title
+	}
+	return label
+}
+
+func previewPersons(e map[string]interface{}) string {
+	if id := pvStr(e, "user_id"); id != "" {
+		return "@" + id
+	}
+	ids := make([]string, 0, len(pvList(e, "persons")))
+	for _, person := range pvList(e, "persons") {
+		ids = append(ids, "@"+pvStr(pvMapOf(person), "id"))
+	}
+	return strings.Join(ids, " ")
+}
+
+// previewSummary 不支持预览的消息, 展示消息类型及内容中的字段
+func previewSummary(doc *previewDoc) string {
+	content := doc.content
+	if pvStr(content, "type") == "template" {
+		content = pvMap(content, "data")
+	}
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{"[" + doc.msgType + "]"}
+	for _, key := range keys {
+		parts = append(parts, key+"="+previewCell(content[key]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func previewCell(v interface{}) string {
+	switch e := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return e
+	case json.Number:
+		return e.String()
+	case bool:
+		return fmt.Sprint(e)
+	}
+	bs, _ := json.Marshal(v)
+	return string(bytes.TrimSpace(bs))
+}
+
+// previewStyled 富文本的文本样式
+func previewStyled(s string, style []interface{}) string {
+	for _, v := range style {
+		switch v {
+		case "bold":
+			s = "<b>" + s + "</b>"
+		case "italic":
+			s = "<i>" + s + "</i>"
+		case "underline":
+			s = "<u>" + s + "</u>"
+		case "lineThrough":
+			s = "<s>" + s + "</s>"
+		}
+	}
+	return s
+}
+
+var (
+	// lark_md 中的 HTML 标签: @用户、链接、字体颜色
+	_previewMDTag = regexp.MustCompile(`<at (?:user_)?id=["']?([^"'\s>]*)["']?>([^<]*)</at>|<a>([^<]*)</a>|<font color=["']?([a-zA-Z0-9#-]+)["']?>([^<]*)</font>`)
+	_previewMDAt  = regexp.MustCompile(`<at (?:user_)?id=["']?([^"'\s>]*)["']?>([^<]*)</at>`)
+
+	_previewMDCode     = regexp.MustCompile("`([^`\n]+)`")
+	_previewMDLink     = regexp.MustCompile(`\[([^\]\n]+)\]\(([^)\s]+)\)`)
+	_previewMDEmphasis = []struct {
+		re   *regexp.Regexp
+		html string
+	}{
+		{regexp.MustCompile(`\*\*(.+?)\*\*`), "<b>$1</b>"},
+		{regexp.MustCompile(`~~(.+?)~~`), "<s>$1</s>"},
+		{regexp.MustCompile(`\*([^*\s][^*\n]*?)\*`), "<i>$1</i>"},
+	}
+)
+
+// previewMarkdown 近似渲染 lark_md, asHTML 为 false 时去除 Markdown 标记
+func previewMarkdown(s string, asHTML bool) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range _previewMDTag.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(previewMDInline(s[last:m[0]], asHTML))
+		sub := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return s[m[2*i]:m[2*i+1]]
+		}
+		switch {
+		case m[2] >= 0:
+			b.WriteString(previewMention(sub(1), sub(2), asHTML))
+		case m[6] >= 0:
+			b.WriteString(previewLink(sub(3), sub(3), asHTML))
+		case asHTML:
+			fmt.Fprintf(&b, "<span style=\"color: %s\">%s</span>", sub(4), previewMDInline(sub(5), true))
+		default:
+			b.WriteString(previewMDInline(sub(5), false))
+		}
+		last = m[1]
+	}
+	b.WriteString(previewMDInline(s[last:], asHTML))
+	if asHTML {
+		return strings.ReplaceAll(b.String(), "\n", "<br>")
+	}
+	return b.String()
+}
+
+func previewMDInline(s string, asHTML bool) string {
+	if !asHTML {
+		for _, r := range _previewMDEmphasis {
+			s = r.re.ReplaceAllString(s, "$1")
+		}
+		return _previewMDLink.ReplaceAllStringFunc(s, func(link string) string {
+			m := _previewMDLink.FindStringSubmatch(link)
+			return previewLink(m[2], m[1], false)
+		})
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range _previewMDCode.FindAllStringSubmatchIndex(s, -1) {
+		b.WriteString(previewMDEmphasis(s[last:m[0]]))
+		b.WriteString("<code>" + html.EscapeString(s[m[2]:m[3]]) + "</code>")
+		last = m[1]
+	}
+	b.WriteString(previewMDEmphasis(s[last:]))
+	return b.String()
+}
+
+func previewMDEmphasis(s string) string {
+	s = html.EscapeString(s)
+	for _, r := range _previewMDEmphasis {
+		s = r.re.ReplaceAllString(s, r.html)
+	}
+	return _previewMDLink.ReplaceAllStringFunc(s, func(link string) string {
+		m := _previewMDLink.FindStringSubmatch(link)
+		return previewLink(html.UnescapeString(m[2]), html.UnescapeString(m[1]), true)
+	})
+}
+
+// previewPlain 文本消息, 仅解析 @用户
+func previewPlain(s string, asHTML bool) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range _previewMDAt.FindAllStringSubmatchIndex(s, -1) {
+		text := s[last:m[0]]
+		if asHTML {
+			text = html.EscapeString(text)
+		}
+		b.WriteString(text)
+		b.WriteString(previewMention(s[m[2]:m[3]], s[m[4]:m[5]], asHTML))
+		last = m[1]
+	}
+	if asHTML {
+		b.WriteString(html.EscapeString(s[last:]))
+		return strings.ReplaceAll(b.String(), "\n", "<br>")
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func previewMention(id, name string, asHTML bool) string {
+	label := name
+	switch {
+	case id == "all":
+		label = "所有人"
+	case label == "":
+		label = id
+	}
+	if asHTML {
+		return "<span class=\"at\">@" + html.EscapeString(label) + "</span>"
+	}
+	return "@" + label
+}
+
+// previewLink 链接, HTML 中仅保留 http(s) 等安全的链接
+func previewLink(href, text string, asHTML bool) string {
+	if text == "" {
+		text = href
+	}
+	if !asHTML {
+		if text == href {
+			return href
+		}
+		return text + " (" + href + ")"
+	}
+	lower := strings.ToLower(href)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "lark://") {
+		return html.EscapeString(text)
+	}
+	return "<a href=\"" + html.EscapeString(href) + "\">" + html.EscapeString(text) + "</a>"
+}
+
+func pvMapOf(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func pvMap(m map[string]interface{}, key string) map[string]interface{} {
+	return pvMapOf(m[key])
+}
+
+func pvList(m map[string]interface{}, key string) []interface{} {
+	l, _ := m[key].([]interface{})
+	return l
+}
+
+func pvStr(m map[string]interface{}, key string) string {
+	switch v := m[key].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
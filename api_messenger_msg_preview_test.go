@@ -0,0 +1,155 @@
+package feishu
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updatePreview = flag.Bool("update", false, "update the preview snapshots in testdata/preview")
+
+func TestMessage_Preview(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *Message
+	}{
+		{
+			name: "text",
+			msg:  NewMessageText("发布完成 " + StrMentionByOpenID("ou_1", "张三") + "\n<b>v1.2.3</b>"),
+		},
+		{
+			name: "post",
+			msg: NewMessagePost(
+				WithPostParagraphs(LangChinese, "发布通知",
+					WithPostParagraph(
+						WithPostElementText("版本 "),
+						WithPostElementStyledText("v1.2.3", PostTextBold),
+						WithPostElementText(" 已发布, 详见 "),
+						WithPostElementLink("文档", "https://example.com/docs"),
+						WithPostElementMentionByOpenID("ou_1", "张三"),
+						WithPostElementEmotion(EmojiOK),
+					),
+					WithPostParagraph(WithPostElementImage("img_1")),
+					WithPostParagraph(WithPostElementCodeBlock("go", "fmt.Println(\"<ok>\")\n")),
+					WithPostParagraph(WithPostElementHorizontalRule()),
+					WithPostParagraph(WithPostElementMarkdown("**bold** and [link](https://example.com)")),
+				),
+				WithPost(LangEnglish, "Release", WithPostElementText("v1.2.3 released")),
+			),
+		},
+		{
+			name: "card",
+			msg: NewMessageCard(BgColorGreen, nil,
+				WithCard(LangChinese, "发布完成",
+					WithCardElementMarkdown("**服务**: <font color='red'>api</font> <at id=all></at>\n[详情](https://example.com)"),
+					WithCardElementFields(
+						WithCardElementField(WithCardElementMarkdown("**版本**\nv1.2.3"), true),
+						WithCardElementField(WithCardElementPlainText("耗时\n3m"), true),
+					),
+					WithCardElementImage("img_1", WithCardElementImageTitle("架构图")),
+					WithCardElementHorizontalRule(),
+					WithCardElementActions(
+						WithCardElementAction(WithCardElementPlainText("查看"), "https://example.com", WithCardElementActionButton(ButtonPrimary)),
+						WithCardElementAction(WithCardElementPlainText("回滚"), "", WithCardElementActionButton(ButtonDanger)),
+						WithCardElementSelectStatic([]CardOption{{Text: "生产", Value: "prod"}, {Text: "预发", Value: "pre"}},
+							WithCardElementActionInitialOption("prod")),
+					),
+					WithCardElementColumnSet([]CardColumn{
+						WithCardColumn([]CardElement{WithCardElementPlainText("左")}, WithCardColumnWidth("weighted"), WithCardColumnWeight(2)),
+						WithCardColumn([]CardElement{WithCardElementPerson("ou_1")}, WithCardColumnWidth("auto")),
+					}, WithCardColumnSetBackgroundStyle("grey")),
+					WithCardElementCollapsiblePanel("变更", []CardElement{
+						WithCardElementTable([]CardTableColumn{
+							{Name: "svc", DisplayName: "服务", Type: CardTableColumnText},
+							{Name: "cost", DisplayName: "耗时", Type: CardTableColumnNumber},
+						}, []map[string]interface{}{{"svc": "api", "cost": 12.5}}),
+					}),
+					WithCardElementNote(WithCardElementPlainText("由 CI 发送"), WithCardElementImage("img_2")),
+				),
+				WithCard(LangEnglish, "Released",
+					WithCardElementMarkdown("**Service**: api"),
+				),
+			),
+		},
+		{
+			name: "card_v2",
+			msg: NewMessageCardV2(
+				WithCardHeader("审批", WithCardHeaderTemplate(BgColorBlue),
+					WithCardHeaderI18nTitle(LangEnglish, "Approval"),
+					WithCardHeaderSubtitle("请在今天处理"), WithCardHeaderTextTag("紧急", "red"),
+				),
+				nil,
+				WithCardElementForm("approve", []CardElement{
+					WithCardElementInput("reason", WithCardElementActionPlaceholder("原因")),
+					WithCardElementChecker(WithCardElementPlainText("同意"), true, WithCardElementActionName("agree")),
+					WithCardElementInteractive(WithCardElementFormSubmitButton(WithCardElementPlainText("提交"), "submit",
+						WithCardElementActionButton(ButtonPrimary))),
+				}),
+				WithCardElementActions(WithCardElementAction(WithCardElementPlainText("查看"), "javascript:alert(1)")),
+				WithCardElementChart(CardChartBar, "耗时", []string{"a", "b"}, []CardChartSeries{{Name: "s", Values: []float64{1, 2}}}),
+			),
+		},
+		{
+			name: "template",
+			msg:  NewMessageCardTemplate("tpl_1", "1.0.0", map[string]string{"status": "running"}),
+		},
+		{
+			name: "image",
+			msg:  NewMessageImage("img_1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := tt.msg.PreviewHTML()
+			requireNil(t, err)
+			checkPreviewSnapshot(t, tt.name+".html", h)
+
+			text, err := tt.msg.PreviewText()
+			requireNil(t, err)
+			checkPreviewSnapshot(t, tt.name+".txt", text)
+		})
+	}
+}
+
+func TestMessage_PreviewText_language(t *testing.T) {
+	msg := NewMessageCard(BgColorDefault, nil,
+		WithCard(LangChinese, "标题", WithCardElementPlainText("内容")),
+		WithCard(LangEnglish, "Title", WithCardElementPlainText("content")),
+	)
+	for lang, want := range map[Language]string{
+		LangChinese:  "# 标题\n\n内容\n",
+		LangEnglish:  "# Title\n\ncontent\n",
+		LangJapanese: "# Title\n\ncontent\n",
+	} {
+		got, err := msg.PreviewText(WithPreviewLanguage(lang))
+		requireNil(t, err)
+		if got != want {
+			t.Errorf("%s: got %q, want %q", lang, got, want)
+		}
+	}
+
+	invalid := NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题",
+		WithCardElementForm("a", []CardElement{WithCardElementForm("b", nil)})))
+	if _, err := invalid.PreviewHTML(); err == nil {
+		t.Fatal("expected an error for an invalid card")
+	}
+}
+
+// checkPreviewSnapshot 对比 testdata/preview 中的快照, 使用 -update 更新快照
+func checkPreviewSnapshot(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "preview", name)
+	if *updatePreview {
+		requireNil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		requireNil(t, os.WriteFile(path, []byte(got), 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	requireNil(t, err)
+	if got != string(want) {
+		t.Errorf("%s differs from the snapshot, run go test -run TestMessage_Preview -update to update it\n got:\n%s\nwant:\n%s",
+			path, got, strings.TrimSpace(string(want)))
+	}
+}
@@ -0,0 +1,425 @@
+package feishu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	_cardMaxElements   = 200   // 单个语言环境的卡片最多的元素数, 包括嵌套的元素
+	_cardMaxActions    = 10    // 单个交互模块最多的交互组件数
+	_cardMaxTextLength = 10000 // 单个文本最多的字符数
+	_cardImageMinWidth = 278   // 图片自定义宽度的最小值
+	_cardImageMaxWidth = 580   // 图片自定义宽度的最大值
+)
+
+// MessageValidationError 消息校验失败, 包含校验发现的所有问题
+type MessageValidationError struct {
+	MsgType  string   // 消息类型
+	Problems []string // 所有问题, 以出错元素的路径开头, 如 zh_cn.elements[1].actions[0]
+}
+
+func (e *MessageValidationError) Error() string {
+	return fmt.Sprintf("message(%s) invalid: %s", e.MsgType, strings.Join(e.Problems, "; "))
+}
+
+// Validate 在发送前校验消息, 一次返回所有问题
+//  校验内容:
+//  - 构造消息时产生的错误, 如容器中不支持的元素
+//  - 元素的嵌套规则, 如输入框只能在表单容器中使用
+//  - 必填字段, 如图片的 img_key、按钮的文本、下拉选择的选项
+//  - 文本长度、元素数量、交互模块中的按钮数、图片自定义宽度
+//  - 多语言卡片各语言环境的标题及元素结构是否一致
+//  - 序列化后的请求体大小
+//  校验通过时返回 nil, 否则返回 *MessageValidationError
+//  发送时可通过 WithSendMessageValidate 自动校验
+func (msg *Message) Validate() error {
+	return msg.validate(true)
+}
+
+// validate checkSize 为 false 时不校验请求体大小, 由调用方处理
+func (msg *Message) validate(checkSize bool) error {
+	v := new(msgValidator)
+	switch c := msg.content.(type) {
+	case map[string]string:
+		if strings.TrimSpace(c["text"]) == "" {
+			v.addf("", "text is empty")
+		}
+	case map[string]interface{}:
+		for _, key := range []string{"image_key", "chat_id", "user_id", "file_key"} {
+			if s, ok := c[key].(string); ok && s == "" && (key != "image_key" || msg.msgType == "image") {
+				v.addf("", "%s is required", key)
+			}
+		}
+	case map[string]postBody:
+		v.validatePost(c)
+	case *msgCard:
+		v.validateCard(c)
+	case *msgCardV2:
+		v.validateCardV2(c)
+	}
+	if msg.err != nil && len(v.problems) == 0 {
+		v.addf("", "%s", msg.err)
+	}
+
+	if checkSize && msg.err == nil {
+		if content, err := msg.marshalContent(); err != nil {
+			v.addf("", "%s", err)
+		} else if err = (&sendMessageRequest{Content: content, MsgType: msg.msgType}).checkSize(); err != nil {
+			v.addf("", "%s", err)
+		}
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &MessageValidationError{MsgType: msg.msgType, Problems: v.problems}
+}
+
+type msgValidator struct {
+	problems []string
+	elements int // 当前语言环境已校验的卡片元素数
+}
+
+func (v *msgValidator) addf(path, format string, args ...interface{}) {
+	problem := fmt.Sprintf(format, args...)
+	if path != "" {
+		problem = path + ": " + problem
+	}
+	v.problems = append(v.problems, problem)
+}
+
+func (v *msgValidator) validatePost(post map[string]postBody) {
+	langs := make([]string, 0, len(post))
+	for lang := range post {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		body := post[lang]
+		if body.Title == "" && len(body.Content) == 0 {
+			v.addf(lang, "post is empty")
+		}
+		for i, para := range body.Content {
+			for j, elem := range para {
+				path := fmt.Sprintf("%s.content[%d][%d]", lang, i, j)
+				switch e := elem.(type) {
+				case postLink:
+					if e.Href == "" {
+						v.addf(path, "link href is empty")
+					}
+				case postAt:
+					if e.UserID == "" {
+						v.addf(path, "at user_id is empty")
+					}
+				case postImage:
+					if e.ImageKey == "" {
+						v.addf(path, "image_key is required")
+					}
+				case postMedia:
+					if e.FileKey == "" {
+						v.addf(path, "media file_key is required")
+					}
+				case postEmotion:
+					if e.EmojiType == "" {
+						v.addf(path, "emoji_type is required")
+					}
+				}
+			}
+		}
+	}
+}
+
+func (v *msgValidator) validateCard(card *msgCard) {
+	langs := make([]string, 0, len(card.I18nElements))
+	for lang := range card.I18nElements {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	var baseShape []string
+	for i, lang := range langs {
+		if card.Header != nil {
+			v.validateText(lang+".title", card.Header.Title.I18n[lang])
+		}
+		v.validateCardElements(lang, card.I18nElements[lang])
+
+		// 各语言环境的元素结构应一致, 以第一个语言环境为准
+		shape := make([]string, 0, len(card.I18nElements[lang]))
+		for _, elem := range card.I18nElements[lang] {
+			shape = append(shape, cardElementShape(elem))
+		}
+		if i == 0 {
+			baseShape = shape
+			continue
+		}
+		if len(shape) != len(baseShape) {
+			v.addf(lang, "%d elements, but %s has %d", len(shape), langs[0], len(baseShape))
+			continue
+		}
+		for j := range shape {
+			if shape[j] != baseShape[j] {
+				v.addf(fmt.Sprintf("%s.elements[%d]", lang, j), "%s differs from %s: %s", shape[j], langs[0], baseShape[j])
+			}
+		}
+	}
+}
+
+func (v *msgValidator) validateCardV2(card *msgCardV2) {
+	if h := card.Header; h != nil {
+		if h.err != nil {
+			v.addf("header", "%s", h.err)
+		}
+		if strings.TrimSpace(h.Title.Content) == "" && len(h.Title.I18nContent) == 0 {
+			v.addf("header", "title is empty")
+		}
+	}
+	v.validateCardElements("", card.Body.Elements)
+}
+
+// validateCardElements 校验一个语言环境的所有元素及元素总数
+func (v *msgValidator) validateCardElements(prefix string, elements []interface{}) {
+	if prefix != "" {
+		prefix += "."
+	}
+	v.elements = 0
+	for i, elem := range elements {
+		v.validateCardElement(fmt.Sprintf("%selements[%d]", prefix, i), elem, false)
+	}
+	if v.elements > _cardMaxElements {
+		v.addf(strings.TrimSuffix(prefix, "."), "%d elements exceeds the limit of %d", v.elements, _cardMaxElements)
+	}
+}
+
+// validateCardElement 校验卡片元素及其子元素, inForm 为元素是否在表单容器中
+func (v *msgValidator) validateCardElement(path string, elem interface{}, inForm bool) {
+	v.elements++
+	switch e := elem.(type) {
+	case invalidCardElement:
+		v.addf(path, "%s", e.err)
+	case *cardText:
+		v.validateCardText(path, e)
+	case *cardDiv:
+		if e.Text == nil && len(e.Fields) == 0 {
+			v.addf(path, "div requires text or fields")
+		}
+		if e.Text != nil {
+			v.validateCardText(path+".text", e.Text)
+		}
+		for i, field := range e.Fields {
+			fp := fmt.Sprintf("%s.fields[%d]", path, i)
+			f, ok := field.(*cardField)
+			if !ok {
+				continue
+			}
+			if text, ok := f.Text.(*cardText); ok {
+				v.validateCardText(fp+".text", text)
+			} else {
+				v.addf(fp, "field only supports text, got %s", cardElementShape(f.Text))
+			}
+		}
+		if e.Extra != nil {
+			v.validateCardElement(path+".extra", e.Extra, inForm)
+		}
+	case *cardImage:
+		if e.ImgKey == "" {
+			v.addf(path, "img_key is required")
+		}
+		if w := e.rawCustomWidth; w != 0 && (w < _cardImageMinWidth || w > _cardImageMaxWidth) {
+			v.addf(path, "custom_width %d out of range [%d, %d]", w, _cardImageMinWidth, _cardImageMaxWidth)
+		}
+	case *cardNote:
+		if len(e.Elements) == 0 {
+			v.addf(path, "note is empty")
+		}
+		for i, sub := range e.Elements {
+			sp := fmt.Sprintf("%s.elements[%d]", path, i)
+			switch sub.(type) {
+			case *cardText, *cardImage, invalidCardElement:
+				v.validateCardElement(sp, sub, inForm)
+			default:
+				v.addf(sp, "note only supports text and image, got %s", cardElementShape(sub))
+			}
+		}
+	case *cardAction:
+		if len(e.Actions) == 0 {
+			v.addf(path, "action is empty")
+		}
+		if n := len(e.Actions); n > _cardMaxActions {
+			v.addf(path, "%d actions exceeds the limit of %d", n, _cardMaxActions)
+		}
+		for i, sub := range e.Actions {
+			sp := fmt.Sprintf("%s.actions[%d]", path, i)
+			if act, ok := sub.(*cardInteractive); ok && (act.Tag == "input" || act.Tag == "checker") {
+				v.addf(sp, "%s is not allowed in action", act.Tag)
+				continue
+			}
+			v.validateCardElement(sp, sub, inForm)
+		}
+	case *cardInteractive:
+		v.validateCardInteractive(path, e, inForm)
+	case *cardColumnSet:
+		if len(e.Columns) == 0 {
+			v.addf(path, "column_set has no columns")
+		}
+		for i, col := range e.Columns {
+			v.validateCardElement(fmt.Sprintf("%s.columns[%d]", path, i), col, inForm)
+		}
+	case *cardColumn:
+		for i, sub := range e.Elements {
+			sp := fmt.Sprintf("%s.elements[%d]", path, i)
+			if act, ok := sub.(*cardInteractive); ok && !inForm {
+				v.addf(sp, "%s in column is only allowed in form", act.Tag)
+				continue
+			}
+			v.validateCardElement(sp, sub, inForm)
+		}
+	case *cardCollapsiblePanel:
+		v.validateText(path+".header.title", e.Header.Title.Content)
+		for i, sub := range e.Elements {
+			v.validateCardElement(fmt.Sprintf("%s.elements[%d]", path, i), sub, inForm)
+		}
+	case *cardForm:
+		v.validateCardForm(path, e)
+	case *cardChart:
+		if e.ChartSpec == nil {
+			v.addf(path, "chart_spec is required")
+		}
+	case *cardPerson:
+		if e.Tag == "person_list" {
+			if len(e.Persons) == 0 {
+				v.addf(path, "person_list is empty")
+			}
+			for i, p := range e.Persons {
+				if p.ID == "" {
+					v.addf(fmt.Sprintf("%s.persons[%d]", path, i), "id is required")
+				}
+			}
+		} else if e.UserID == "" {
+			v.addf(path, "user_id is required")
+		}
+	}
+}
+
+func (v *msgValidator) validateCardInteractive(path string, e *cardInteractive, inForm bool) {
+	formButton := e.ActionType == "form_submit" || e.ActionType == "form_reset" || e.FormActionType != ""
+	switch {
+	case e.Tag == "input" && !inForm:
+		v.addf(path, "input is only allowed in form")
+	case formButton && !inForm:
+		v.addf(path, "form button is only allowed in form")
+	}
+
+	switch e.Tag {
+	case "button", "checker":
+		if text, ok := e.Text.(*cardText); ok {
+			v.validateCardText(path+".text", text)
+		} else {
+			v.addf(path, "%s text is required", e.Tag)
+		}
+	case "select_static", "multi_select_static", "overflow":
+		if len(e.Options) == 0 {
+			v.addf(path, "%s has no options", e.Tag)
+		}
+		for i, opt := range e.Options {
+			if opt.Value == "" {
+				v.addf(fmt.Sprintf("%s.options[%d]", path, i), "value is required")
+			}
+		}
+	}
+}
+
+func (v *msgValidator) validateCardForm(path string, e *cardForm) {
+	if e.Name == "" {
+		v.addf(path, "form name is required")
+	}
+	for i, sub := range e.Elements {
+		v.validateCardElement(fmt.Sprintf("%s.elements[%d]", path, i), sub, true)
+	}
+
+	// 交互组件的 name 在表单内唯一, 提交按钮可在多列布局中
+	names := make(map[string]bool)
+	hasSubmit := false
+	for _, sub := range e.Elements {
+		_ = walkCardElement(sub, func(elem interface{}) error {
+			act, ok := elem.(*cardInteractive)
+			if !ok {
+				return nil
+			}
+			if act.ActionType == "form_submit" || act.FormActionType == "submit" {
+				hasSubmit = true
+			}
+			if act.Name != "" && names[act.Name] {
+				v.addf(path, "duplicate name %q", act.Name)
+			}
+			names[act.Name] = true
+			return nil
+		})
+	}
+	if !hasSubmit {
+		v.addf(path, "form has no submit button")
+	}
+}
+
+func (v *msgValidator) validateCardText(path string, t *cardText) {
+	if len(t.I18nContent) == 0 {
+		v.validateText(path, t.Content)
+		return
+	}
+	langs := make([]string, 0, len(t.I18nContent))
+	for lang := range t.I18nContent {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		v.validateText(path+"."+lang, t.I18nContent[lang])
+	}
+}
+
+func (v *msgValidator) validateText(path, text string) {
+	if strings.TrimSpace(text) == "" {
+		v.addf(path, "text is empty")
+	}
+	if n := utf8.RuneCountInString(text); n > _cardMaxTextLength {
+		v.addf(path, "text length %d exceeds the limit of %d", n, _cardMaxTextLength)
+	}
+}
+
+// cardElementShape 卡片元素的结构, 由元素及其子元素的 tag 组成, 如 column_set(column(div,img))
+func cardElementShape(elem interface{}) string {
+	var children []interface{}
+	switch e := elem.(type) {
+	case invalidCardElement:
+		return "invalid"
+	case *cardText:
+		return e.Tag
+	case *cardNote:
+		children = e.Elements
+	case *cardAction:
+		children = e.Actions
+	case *cardColumnSet:
+		children = e.Columns
+	case *cardColumn:
+		children = e.Elements
+	case *cardCollapsiblePanel:
+		children = e.Elements
+	case *cardForm:
+		children = e.Elements
+	}
+
+	tag := fmt.Sprintf("%T", elem)
+	if e, ok := elem.(cardElement); ok {
+		tag = e.cardTag()
+	}
+	if len(children) == 0 {
+		return tag
+	}
+	shapes := make([]string, 0, len(children))
+	for _, sub := range children {
+		shapes = append(shapes, cardElementShape(sub))
+	}
+	return tag + "(" + strings.Join(shapes, ",") + ")"
+}
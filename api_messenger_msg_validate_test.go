@@ -0,0 +1,179 @@
+package feishu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMessage_Validate(t *testing.T) {
+	buttons := make([]CardElementAction, 0, 11)
+	for i := 0; i < 11; i++ {
+		buttons = append(buttons, WithCardElementAction(WithCardElementPlainText("查看"), "https://example.com"))
+	}
+
+	tests := []struct {
+		name string
+		msg  *Message
+		want []string
+	}{
+		{
+			name: "valid card",
+			msg: NewMessageCard(BgColorGreen, nil,
+				WithCard(LangChinese, "发布完成",
+					WithCardElementMarkdown("**v1.2.3**"),
+					WithCardElementImage("img_1", WithCardElementImageCustomWidth(300)),
+					WithCardElementActions(WithCardElementAction(WithCardElementPlainText("查看"), "https://example.com")),
+				),
+				WithCard(LangEnglish, "Released",
+					WithCardElementMarkdown("**v1.2.3**"),
+					WithCardElementImage("img_1", WithCardElementImageCustomWidth(300)),
+					WithCardElementActions(WithCardElementAction(WithCardElementPlainText("View"), "https://example.com")),
+				),
+			),
+		},
+		{
+			name: "invalid card",
+			msg: NewMessageCard(BgColorGreen, nil,
+				WithCard(LangChinese, "",
+					WithCardElementImage("", WithCardElementImageCustomWidth(1000)),
+					WithCardElementActions(buttons[0], buttons[1:]...),
+					WithCardElementInput("reason"),
+				),
+				WithCard(LangEnglish, "Released",
+					WithCardElementHorizontalRule(),
+					WithCardElementActions(WithCardElementAction(WithCardElementPlainText(""), "")),
+				),
+			),
+			want: []string{
+				"en_us.elements[1].actions[0].text: text is empty",
+				"zh_cn.title: text is empty",
+				"zh_cn.elements[0]: img_key is required",
+				"zh_cn.elements[0]: custom_width 1000 out of range [278, 580]",
+				"zh_cn.elements[1]: 11 actions exceeds the limit of 10",
+				"zh_cn.elements[2]: input is only allowed in form",
+				"zh_cn: 3 elements, but en_us has 2",
+			},
+		},
+		{
+			name: "i18n structure",
+			msg: NewMessageCard(BgColorDefault, nil,
+				WithCard(LangChinese, "标题", WithCardElementPlainText("a"), WithCardElementHorizontalRule()),
+				WithCard(LangEnglish, "Title", WithCardElementPlainText("a"), WithCardElementImage("img_1")),
+			),
+			want: []string{"zh_cn.elements[1]: hr differs from en_us: img"},
+		},
+		{
+			name: "form",
+			msg: NewMessageCardV2(WithCardHeader("审批"), nil,
+				WithCardElementForm("approve", []CardElement{
+					WithCardElementInput("reason"),
+					WithCardElementChecker(WithCardElementPlainText("紧急"), false, WithCardElementActionName("reason")),
+				}),
+			),
+			want: []string{
+				`elements[0]: duplicate name "reason"`,
+				"elements[0]: form has no submit button",
+			},
+		},
+		{
+			name: "checker without text",
+			msg: NewMessageCardV2(WithCardHeader("审批"), nil,
+				WithCardElementForm("approve", []CardElement{
+					WithCardElementChecker(nil, true, WithCardElementActionName("agree")),
+					WithCardElementInteractive(WithCardElementFormSubmitButton(WithCardElementPlainText("提交"), "submit")),
+				}),
+			),
+			want: []string{"elements[0].elements[0]: checker text is required"},
+		},
+		{
+			name: "form buttons in columns",
+			msg: NewMessageCardV2(WithCardHeader("审批"), nil,
+				WithCardElementForm("approve", []CardElement{
+					WithCardElementInput("reason"),
+					WithCardElementColumnSet([]CardColumn{
+						WithCardColumn([]CardElement{WithCardElementInteractive(WithCardElementFormSubmitButton(WithCardElementPlainText("提交"), "submit"))}),
+						WithCardColumn([]CardElement{WithCardElementInteractive(WithCardElementFormResetButton(WithCardElementPlainText("重置"), "reset"))}),
+					}),
+				}),
+			),
+		},
+		{
+			name: "interactive in column outside form",
+			msg: NewMessageCardV2(WithCardHeader("审批"), nil,
+				WithCardElementColumnSet([]CardColumn{WithCardColumn([]CardElement{WithCardElementInput("reason")})}),
+			),
+			want: []string{"elements[0].columns[0].elements[0]: input in column is only allowed in form"},
+		},
+		{
+			name: "construction errors",
+			msg: NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题",
+				WithCardElementForm("a", []CardElement{WithCardElementForm("b", nil)}),
+				WithCardElementColumnSet([]CardColumn{WithCardColumn([]CardElement{WithCardElementForm("c", nil)})}),
+			)),
+			want: []string{
+				"zh_cn.elements[0]: card form: card element not allowed in container",
+				"zh_cn.elements[1]: card column: card element not allowed in container",
+			},
+		},
+		{
+			name: "post",
+			msg: NewMessagePost(WithPost(LangChinese, "标题",
+				WithPostElementLink("文档", ""),
+				WithPostElementImage(""),
+			)),
+			want: []string{
+				"zh_cn.content[0][0]: link href is empty",
+				"zh_cn.content[1][0]: image_key is required",
+			},
+		},
+		{
+			name: "text",
+			msg:  NewMessageText(" "),
+			want: []string{"text is empty"},
+		},
+		{
+			name: "too large",
+			msg: NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题",
+				WithCardElementMarkdown(strings.Repeat("a", 9000)),
+				WithCardElementMarkdown(strings.Repeat("b", 9000)),
+				WithCardElementMarkdown(strings.Repeat("c", 9000)),
+				WithCardElementMarkdown(strings.Repeat("d", 9000)),
+			)),
+			want: []string{"message(interactive) too large"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.Validate()
+			if len(tt.want) == 0 {
+				requireNil(t, err)
+				return
+			}
+			var verr *MessageValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got %v, want *MessageValidationError", err)
+			}
+			if len(verr.Problems) != len(tt.want) {
+				t.Fatalf("got %d problems, want %d:\n%s", len(verr.Problems), len(tt.want), strings.Join(verr.Problems, "\n"))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(verr.Problems[i], want) {
+					t.Errorf("problem %d\n got: %s\nwant: %s", i, verr.Problems[i], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_app_SendMessage_validate(t *testing.T) {
+	fsApp := newApp("cli_test", "secret", WithAppOpenBaseURL("http://127.0.0.1:0"))
+	fsApp.isCustomApp = true
+
+	msg := NewMessageCard(BgColorDefault, nil, WithCard(LangChinese, "标题", WithCardElementImage("")))
+	_, err := fsApp.SendMessage(MessageReceiver{ID: "oc_1", IDType: ChatID}, msg, WithSendMessageValidate())
+	var verr *MessageValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("got %v, want *MessageValidationError", err)
+	}
+}
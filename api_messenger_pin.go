@@ -0,0 +1,243 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type Pin struct {
+	MessageID      string `json:"message_id"`       // Pin 的消息 ID
+	ChatID         string `json:"chat_id"`          // Pin 消息所在的群聊 ID
+	OperatorID     string `json:"operator_id"`      // Pin 的操作人 ID
+	OperatorIDType string `json:"operator_id_type"` // Pin 的操作人 ID 类型。当 Pin 的操作人为用户时，为open_id；当 Pin 的操作人为机器人时，为app_id
+	CreateTime     string `json:"create_time"`      // Pin 的创建时间（毫秒级时间戳）
+}
+
+// 名称: [消息与群组] Pin 消息
+// Func: [api_messenger_pin.go] PinMessage
+//
+// 描述: Pin 一条指定的消息
+// Info: 需要开启机器人能力
+// Info: 机器人需要在消息所在的会话内
+// Info: 对同一条消息进行 Pin 操作，只有第一次会成功，后续的 Pin 操作会返回已经存在的 Pin 信息
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/pin/create
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/pins
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type pinMessageRequest struct {
+	MessageID string `json:"message_id"` // 待 Pin 的消息 ID
+}
+
+type pinMessageResponse struct {
+	fsResponse
+	Data struct {
+		Pin Pin `json:"pin"`
+	} `json:"data"`
+}
+
+func (a *app) PinMessage(messageID string) (Pin, error) {
+	return a.PinMessageWithContext(context.Background(), messageID)
+}
+
+func (a *app) PinMessageWithContext(ctx context.Context, messageID string) (Pin, error) {
+	apiDomain := "消息与群组"
+	apiName := "Pin 消息"
+	urlSuffix := "/open-apis/im/v1/pins"
+
+	if !a.isSupported(true, true) {
+		return Pin{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &pinMessageRequest{
+		MessageID: messageID,
+	}
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return Pin{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return Pin{}, err
+	}
+
+	resp := new(pinMessageResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return Pin{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return Pin{}, err
+	}
+
+	return resp.Data.Pin, nil
+}
+
+// 名称: [消息与群组] 移除 Pin 消息
+// Func: [api_messenger_pin.go] UnpinMessage
+//
+// 描述: 移除一条指定消息的 Pin
+// Info: 需要开启机器人能力
+// Info: 机器人需要在消息所在的会话内
+// Info: 移除一条已经不存在的 Pin 消息时，不会报错
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/pin/delete
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/pins/:message_id
+// HTTP Method: DELETE
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+
+func (a *app) UnpinMessage(messageID string) error {
+	return a.UnpinMessageWithContext(context.Background(), messageID)
+}
+
+func (a *app) UnpinMessageWithContext(ctx context.Context, messageID string) error {
+	apiDomain := "消息与群组"
+	apiName := "移除 Pin 消息"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/pins/%s", messageID)
+
+	if !a.isSupported(true, true) {
+		return fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._deleteWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return err
+	}
+
+	resp := new(fsResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return err
+	}
+
+	return resp.check(reqID, apiDomain, apiName)
+}
+
+// 名称: [消息与群组] 获取群内 Pin 消息
+// Func: [api_messenger_pin.go] GetChatPins
+//
+// 描述: 获取所在群内指定时间范围内的所有 Pin 消息
+// Info: 需要开启机器人能力
+// Info: 获取 Pin 消息时，机器人必须在群组中
+// Info: 返回的 Pin 消息按 Pin 的创建时间降序排列
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/pin/list
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/pins
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+type chatPinsResponse struct {
+	fsResponse
+	Data ChatPinsResponse `json:"data"`
+}
+
+type ChatPinsResponse struct {
+	Items     []Pin  `json:"items"`      // Pin 的操作信息
+	PageToken string `json:"page_token"` // 分页标记，当 has_more 为 true 时，会同时返回新的 page_token，否则不返回 page_token
+	HasMore   bool   `json:"has_more"`   // 是否还有更多项
+}
+
+type GetChatPinsOption = doOption
+
+// WithGetChatPinsStartTime Pin 信息的起始时间
+//  若未填写默认获取到群聊内最早的 Pin 信息
+func WithGetChatPinsStartTime(t time.Time) GetChatPinsOption {
+	return withDoQueryKV("start_time", strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10))
+}
+
+// WithGetChatPinsEndTime Pin 信息的结束时间
+//  若未填写默认从群聊内最新的 Pin 信息开始获取
+func WithGetChatPinsEndTime(t time.Time) GetChatPinsOption {
+	return withDoQueryKV("end_time", strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10))
+}
+
+func WithGetChatPinsPageSize(pageSize int) GetChatPinsOption {
+	return withDoQueryKV("page_size", strconv.Itoa(pageSize))
+}
+
+func WithGetChatPinsPageToken(pageToken string) GetChatPinsOption {
+	return withDoQueryKV("page_token", pageToken)
+}
+
+func WithGetChatPinsNextPage(lastResp ChatPinsResponse) GetChatPinsOption {
+	if !lastResp.HasMore {
+		return nil
+	}
+	return withDoQueryKV("page_token", lastResp.PageToken)
+}
+
+// GetChatPins 获取群内 Pin 消息
+//  chatID 可使用 MessageDetail.ChatID / EventMessage.ChatID
+func (a *app) GetChatPins(chatID string, opts ...GetChatPinsOption) (ChatPinsResponse, error) {
+	return a.GetChatPinsWithContext(context.Background(), chatID, opts...)
+}
+
+func (a *app) GetChatPinsWithContext(ctx context.Context, chatID string, opts ...GetChatPinsOption) (ChatPinsResponse, error) {
+	apiDomain := "消息与群组"
+	apiName := "获取群内 Pin 消息"
+	urlSuffix := "/open-apis/im/v1/pins"
+
+	if !a.isSupported(true, true) {
+		return ChatPinsResponse{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return ChatPinsResponse{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	opts = append([]GetChatPinsOption{withDoQueryKV("chat_id", chatID)}, opts...)
+	doOpts := a.buildOpts(apiDomain, apiName, header, opts...)
+	reqID, reader, err := a._getWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return ChatPinsResponse{}, err
+	}
+
+	resp := new(chatPinsResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return ChatPinsResponse{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return ChatPinsResponse{}, err
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,43 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_PinMessage(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	messageID := "om_d85801e64eb4135954cd657d4e11c8df"
+
+	msgs, err := fsApp.GetMessage(messageID)
+	requireNil(t, err)
+	if len(msgs) == 0 {
+		t.Fatal("message not found")
+	}
+
+	pin, err := fsApp.PinMessage(messageID)
+	requireNil(t, err)
+
+	logIndent(t, pin)
+
+	pins := make([]Pin, 0, 12)
+	pageSize := 1
+	pinsResp, err := fsApp.GetChatPins(msgs[0].ChatID, WithGetChatPinsPageSize(pageSize))
+	requireNil(t, err)
+	pins = append(pins, pinsResp.Items...)
+
+	for pinsResp.HasMore {
+		pinsResp, err = fsApp.GetChatPins(msgs[0].ChatID,
+			WithGetChatPinsPageSize(pageSize),
+			WithGetChatPinsNextPage(pinsResp),
+		)
+		requireNil(t, err)
+		pins = append(pins, pinsResp.Items...)
+	}
+
+	logIndent(t, pins)
+
+	err = fsApp.UnpinMessage(messageID)
+	requireNil(t, err)
+}
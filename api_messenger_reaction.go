@@ -0,0 +1,315 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// EmojiType 表情回复的表情类型
+//  完整列表: https://open.feishu.cn/document/server-docs/im-v1/message-reaction/emojis-introduce
+type EmojiType string
+
+const (
+	EmojiOK            EmojiType = "OK"
+	EmojiThumbsUp      EmojiType = "THUMBSUP"
+	EmojiThumbsDown    EmojiType = "ThumbsDown"
+	EmojiThanks        EmojiType = "THANKS"
+	EmojiMuscle        EmojiType = "MUSCLE"
+	EmojiFingerHeart   EmojiType = "FINGERHEART"
+	EmojiApplause      EmojiType = "APPLAUSE"
+	EmojiFistBump      EmojiType = "FISTBUMP"
+	EmojiJiaYi         EmojiType = "JIAYI"
+	EmojiDone          EmojiType = "DONE"
+	EmojiSmile         EmojiType = "SMILE"
+	EmojiBlush         EmojiType = "BLUSH"
+	EmojiLaugh         EmojiType = "LAUGH"
+	EmojiSmirk         EmojiType = "SMIRK"
+	EmojiLOL           EmojiType = "LOL"
+	EmojiFacepalm      EmojiType = "FACEPALM"
+	EmojiLove          EmojiType = "LOVE"
+	EmojiWink          EmojiType = "WINK"
+	EmojiProud         EmojiType = "PROUD"
+	EmojiThinking      EmojiType = "THINKING"
+	EmojiSob           EmojiType = "SOB"
+	EmojiCry           EmojiType = "CRY"
+	EmojiError         EmojiType = "ERROR"
+	EmojiWow           EmojiType = "WOW"
+	EmojiYeah          EmojiType = "YEAH"
+	EmojiClap          EmojiType = "CLAP"
+	EmojiPraise        EmojiType = "PRAISE"
+	EmojiWave          EmojiType = "WAVE"
+	EmojiWhat          EmojiType = "WHAT"
+	EmojiShocked       EmojiType = "SHOCKED"
+	EmojiSweat         EmojiType = "SWEAT"
+	EmojiSpeechless    EmojiType = "SPEECHLESS"
+	EmojiHug           EmojiType = "HUG"
+	EmojiSalute        EmojiType = "SALUTE"
+	EmojiHighFive      EmojiType = "HIGHFIVE"
+	EmojiHeart         EmojiType = "HEART"
+	EmojiHeartBroken   EmojiType = "HEARTBROKEN"
+	EmojiRose          EmojiType = "ROSE"
+	EmojiParty         EmojiType = "PARTY"
+	EmojiBeer          EmojiType = "BEER"
+	EmojiCoffee        EmojiType = "Coffee"
+	EmojiGet           EmojiType = "Get"
+	EmojiLGTM          EmojiType = "LGTM"
+	EmojiOnIt          EmojiType = "OnIt"
+	EmojiOneSecond     EmojiType = "OneSecond"
+	EmojiTyping        EmojiType = "Typing"
+	EmojiYes           EmojiType = "Yes"
+	EmojiNo            EmojiType = "No"
+	EmojiCheckMark     EmojiType = "CheckMark"
+	EmojiCrossMark     EmojiType = "CrossMark"
+	EmojiMinusOne      EmojiType = "MinusOne"
+	EmojiHundred       EmojiType = "Hundred"
+	EmojiPin           EmojiType = "Pin"
+	EmojiAlarm         EmojiType = "Alarm"
+	EmojiLoudspeaker   EmojiType = "Loudspeaker"
+	EmojiTrophy        EmojiType = "Trophy"
+	EmojiFire          EmojiType = "Fire"
+	EmojiBomb          EmojiType = "BOMB"
+	EmojiSkull         EmojiType = "SKULL"
+	EmojiPoop          EmojiType = "POOP"
+	EmojiEyesClosed    EmojiType = "EYESCLOSED"
+	EmojiYouAreTheBest EmojiType = "YouAreTheBest"
+)
+
+type MessageReaction struct {
+	ReactionID   string           `json:"reaction_id"`   // reaction 资源 ID
+	Operator     ReactionOperator `json:"operator"`      // 添加 reaction 的操作人
+	ActionTime   string           `json:"action_time"`   // reaction 动作的 unix 时间戳（毫秒）
+	ReactionType ReactionType     `json:"reaction_type"` // reaction 资源类型
+}
+
+type ReactionOperator struct {
+	OperatorID   string `json:"operator_id"`   // 操作人 ID
+	OperatorType string `json:"operator_type"` // 操作人身份，用户或应用: app, user
+}
+
+type ReactionType struct {
+	EmojiType EmojiType `json:"emoji_type"` // emoji 类型
+}
+
+type messageReactionResponse struct {
+	fsResponse
+	Data MessageReaction `json:"data"`
+}
+
+// 名称: [消息与群组] 添加消息表情回复
+// Func: [api_messenger_reaction.go] CreateMessageReaction
+//
+// 描述: 给指定消息添加指定类型的表情回复（reaction 即表情回复，本文档统一用“reaction”代称）
+// Info: 需要开启机器人能力
+// Info: 待添加 reaction 的消息要真实存在，不能被撤回
+// Info: 给消息添加 reaction，需要 reaction 的发送方（机器人或者用户）在消息所在的会话内
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-reaction/create
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id/reactions
+// HTTP Method: POST
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type createMessageReactionRequest struct {
+	ReactionType ReactionType `json:"reaction_type"` // reaction 资源类型
+}
+
+func (a *app) CreateMessageReaction(messageID string, emojiType EmojiType) (MessageReaction, error) {
+	return a.CreateMessageReactionWithContext(context.Background(), messageID, emojiType)
+}
+
+func (a *app) CreateMessageReactionWithContext(ctx context.Context, messageID string, emojiType EmojiType) (MessageReaction, error) {
+	apiDomain := "消息与群组"
+	apiName := "添加消息表情回复"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/reactions", messageID)
+
+	if !a.isSupported(true, true) {
+		return MessageReaction{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	data := &createMessageReactionRequest{
+		ReactionType: ReactionType{EmojiType: emojiType},
+	}
+	header := map[string]string{
+		"Content-Type":  "application/json; charset=utf-8",
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageReaction{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._postWithContext(ctx, urlSuffix, data, doOpts...)
+	if err != nil {
+		return MessageReaction{}, err
+	}
+
+	resp := new(messageReactionResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MessageReaction{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MessageReaction{}, err
+	}
+
+	return resp.Data, nil
+}
+
+// 名称: [消息与群组] 获取消息表情回复
+// Func: [api_messenger_reaction.go] GetMessageReactions
+//
+// 描述: 获取指定消息的特定类型或者所有类型的 reaction 列表
+// Info: 需要开启机器人能力
+// Info: 获取消息的 reaction，需要 reaction 的获取方（机器人或者用户）在消息所在的会话内
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-reaction/list
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id/reactions
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+type messageReactionsResponse struct {
+	fsResponse
+	Data MessageReactionsResponse `json:"data"`
+}
+
+type MessageReactionsResponse struct {
+	Items     []MessageReaction `json:"items"`      // reaction 列表
+	PageToken string            `json:"page_token"` // 分页标记，当 has_more 为 true 时，会同时返回新的 page_token，否则不返回 page_token
+	HasMore   bool              `json:"has_more"`   // 是否还有更多项
+}
+
+type GetMessageReactionsOption = doOption
+
+// WithGetMessageReactionsEmojiType 仅获取指定类型的 reaction
+func WithGetMessageReactionsEmojiType(emojiType EmojiType) GetMessageReactionsOption {
+	return withDoQueryKV("reaction_type", string(emojiType))
+}
+
+// WithGetMessageReactionsOperatorIDType 控制 ReactionOperator.OperatorID 的类型
+//  仅支持 OpenID, UnionID, UserID
+func WithGetMessageReactionsOperatorIDType(idType IDType) GetMessageReactionsOption {
+	return withDoQueryKV("user_id_type", string(idType))
+}
+
+func WithGetMessageReactionsPageSize(pageSize int) GetMessageReactionsOption {
+	return withDoQueryKV("page_size", strconv.Itoa(pageSize))
+}
+
+func WithGetMessageReactionsPageToken(pageToken string) GetMessageReactionsOption {
+	return withDoQueryKV("page_token", pageToken)
+}
+
+func WithGetMessageReactionsNextPage(lastResp MessageReactionsResponse) GetMessageReactionsOption {
+	if !lastResp.HasMore {
+		return nil
+	}
+	return withDoQueryKV("page_token", lastResp.PageToken)
+}
+
+func (a *app) GetMessageReactions(messageID string, opts ...GetMessageReactionsOption) (MessageReactionsResponse, error) {
+	return a.GetMessageReactionsWithContext(context.Background(), messageID, opts...)
+}
+
+func (a *app) GetMessageReactionsWithContext(ctx context.Context, messageID string, opts ...GetMessageReactionsOption) (MessageReactionsResponse, error) {
+	apiDomain := "消息与群组"
+	apiName := "获取消息表情回复"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/reactions", messageID)
+
+	if !a.isSupported(true, true) {
+		return MessageReactionsResponse{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageReactionsResponse{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header, opts...)
+	reqID, reader, err := a._getWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return MessageReactionsResponse{}, err
+	}
+
+	resp := new(messageReactionsResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MessageReactionsResponse{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MessageReactionsResponse{}, err
+	}
+
+	return resp.Data, nil
+}
+
+// 名称: [消息与群组] 删除消息表情回复
+// Func: [api_messenger_reaction.go] DeleteMessageReaction
+//
+// 描述: 删除指定消息的某一 reaction
+// Info: 需要开启机器人能力
+// Info: 只能删除真实存在的 reaction，并且删除 reaction 请求的操作者必须是 reaction 的原始添加者
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message-reaction/delete
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id/reactions/:reaction_id
+// HTTP Method: DELETE
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+
+func (a *app) DeleteMessageReaction(messageID, reactionID string) (MessageReaction, error) {
+	return a.DeleteMessageReactionWithContext(context.Background(), messageID, reactionID)
+}
+
+func (a *app) DeleteMessageReactionWithContext(ctx context.Context, messageID, reactionID string) (MessageReaction, error) {
+	apiDomain := "消息与群组"
+	apiName := "删除消息表情回复"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/reactions/%s", messageID, reactionID)
+
+	if !a.isSupported(true, true) {
+		return MessageReaction{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageReaction{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header)
+	reqID, reader, err := a._deleteWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return MessageReaction{}, err
+	}
+
+	resp := new(messageReactionResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MessageReaction{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MessageReaction{}, err
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,38 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_CreateMessageReaction(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	messageID := "om_d85801e64eb4135954cd657d4e11c8df"
+
+	reaction, err := fsApp.CreateMessageReaction(messageID, EmojiCheckMark)
+	requireNil(t, err)
+
+	logIndent(t, reaction)
+
+	var reactions []MessageReaction
+	reactionsResp, err := fsApp.GetMessageReactions(messageID, WithGetMessageReactionsPageSize(1))
+	requireNil(t, err)
+	reactions = append(reactions, reactionsResp.Items...)
+
+	for reactionsResp.HasMore {
+		reactionsResp, err = fsApp.GetMessageReactions(messageID,
+			WithGetMessageReactionsPageSize(1),
+			WithGetMessageReactionsNextPage(reactionsResp),
+		)
+		requireNil(t, err)
+		reactions = append(reactions, reactionsResp.Items...)
+	}
+
+	logIndent(t, reactions)
+
+	reaction, err = fsApp.DeleteMessageReaction(messageID, reaction.ReactionID)
+	requireNil(t, err)
+
+	logIndent(t, reaction)
+}
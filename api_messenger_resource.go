@@ -0,0 +1,139 @@
+package feishu
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MessageResourceType 消息中资源文件的类型
+type MessageResourceType string
+
+const (
+	MessageResourceImage MessageResourceType = "image" // 图片
+	MessageResourceFile  MessageResourceType = "file"  // 文件、音频、视频（表情包除外）
+)
+
+// MessageResource 下载得到的资源文件
+//  Body 为流式读取的文件内容, 使用完毕后需要调用方关闭
+type MessageResource struct {
+	Body          io.ReadCloser
+	ContentType   string // 响应头 Content-Type
+	ContentLength int64  // 响应头 Content-Length, 未知时为 -1
+	Filename      string // 从响应头 Content-Disposition 中解析出的文件名, 可能为空
+}
+
+// _resourceErrPeekSize 状态码为 200 的 JSON 响应, 预读不超过该大小的完整内容以判断是否为错误
+const _resourceErrPeekSize = 4 << 10
+
+type messageResourceBody struct {
+	io.Reader
+	io.Closer
+}
+
+func (a *app) _newMessageResource(apiDomain, apiName, reqID string, resp *http.Response) (MessageResource, error) {
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if resp.StatusCode != http.StatusOK {
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		// 错误时返回的是 JSON
+		if mediaType == "application/json" {
+			fsResp := new(fsResponse)
+			if err := json.NewDecoder(resp.Body).Decode(fsResp); err == nil {
+				if err = fsResp.check(reqID, apiDomain, apiName); err != nil {
+					return MessageResource{}, err
+				}
+			}
+		}
+		return MessageResource{}, fmt.Errorf(_fmtErrReq, apiDomain, apiName, reqID, fmt.Errorf("unexpected status: %s", resp.Status))
+	}
+
+	var body io.ReadCloser = resp.Body
+	if mediaType == "application/json" {
+		// 资源文件本身也可能是 JSON, 仅当完整内容可解析且 code 非 0 时视为错误
+		br := bufio.NewReaderSize(resp.Body, _resourceErrPeekSize)
+		if bs, err := br.Peek(_resourceErrPeekSize); err == io.EOF {
+			fsResp := new(fsResponse)
+			if json.Unmarshal(bs, fsResp) == nil {
+				if err = fsResp.check(reqID, apiDomain, apiName); err != nil {
+					_ = resp.Body.Close()
+					return MessageResource{}, err
+				}
+			}
+		}
+		body = messageResourceBody{Reader: br, Closer: resp.Body}
+	}
+
+	var filename string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		filename = strings.TrimSpace(params["filename"])
+	}
+
+	return MessageResource{
+		Body:          body,
+		ContentType:   contentType,
+		ContentLength: resp.ContentLength,
+		Filename:      filename,
+	}, nil
+}
+
+// 名称: [消息与群组] 获取消息中的资源文件
+// Func: [api_messenger_resource.go] GetMessageResource
+//
+// 描述: 获取消息中的资源文件，包括音频，视频，图片和文件，暂不支持表情包资源下载。当前仅支持 100M 以内的资源文件的下载
+// Info: 需要开启机器人能力
+// Info: 机器人和消息需要在同一会话中
+// Info: 请求的 file_key 和 message_id 需要匹配
+// Info: 暂不支持获取合并转发消息中的子消息的资源文件
+// Info: 获取音频、视频、文件时 resourceType 均为 MessageResourceFile
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message/get-2
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages/:message_id/resources/:file_key
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+
+func (a *app) GetMessageResource(messageID, key string, resourceType MessageResourceType) (MessageResource, error) {
+	return a.GetMessageResourceWithContext(context.Background(), messageID, key, resourceType)
+}
+
+func (a *app) GetMessageResourceWithContext(ctx context.Context, messageID, key string, resourceType MessageResourceType) (MessageResource, error) {
+	apiDomain := "消息与群组"
+	apiName := "获取消息中的资源文件"
+	urlSuffix := fmt.Sprintf("/open-apis/im/v1/messages/%s/resources/%s", messageID, key)
+
+	if !a.isSupported(true, true) {
+		return MessageResource{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessageResource{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	doOpts := a.buildOpts(apiDomain, apiName, header,
+		withDoQueryKV("type", string(resourceType)),
+	)
+	reqID, resp, err := a._getStreamWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return MessageResource{}, err
+	}
+
+	return a._newMessageResource(apiDomain, apiName, reqID, resp)
+}
@@ -0,0 +1,86 @@
+package feishu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_app_GetMessageResource(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	res, err := fsApp.GetMessageResource("om_d85801e64eb4135954cd657d4e11c8df", "file_v2_9f1c5b2e-7d3a-4b6f-8e0a-2c4d6e8f0a1g", MessageResourceFile)
+	requireNil(t, err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	filename := res.Filename
+	if filename == "" {
+		filename = "resource"
+	}
+	f, err := os.Create(filepath.Join(t.TempDir(), filename))
+	requireNil(t, err)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	n, err := io.Copy(f, res.Body)
+	requireNil(t, err)
+
+	t.Log(res.ContentType, res.Filename, n)
+}
+
+func Test_app_GetMessageResource_json(t *testing.T) {
+	large := `{"code":1,"items":"` + strings.Repeat("a", 2*_resourceErrPeekSize) + `"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/auth/v3/app_access_token/internal":
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"ok","app_access_token":"t-test","expire":7200}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch r.URL.Path {
+		case "/open-apis/im/v1/messages/om_1/resources/file_json":
+			w.Header().Set("Content-Disposition", `attachment; filename="data.json"`)
+			_, _ = fmt.Fprint(w, `{"name":"data"}`)
+		case "/open-apis/im/v1/messages/om_1/resources/file_large":
+			_, _ = fmt.Fprint(w, large)
+		case "/open-apis/im/v1/messages/om_1/resources/file_code":
+			_, _ = fmt.Fprint(w, `{"code":234003,"msg":"File not in msg."}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"code":234001,"msg":"Invalid request param."}`)
+		}
+	}))
+	defer srv.Close()
+
+	fsApp := newApp("cli_test", "secret", WithAppOpenBaseURL(srv.URL))
+	fsApp.isCustomApp = true
+
+	for key, want := range map[string]string{"file_json": `{"name":"data"}`, "file_large": large} {
+		res, err := fsApp.GetMessageResource("om_1", key, MessageResourceFile)
+		requireNil(t, err)
+		bs, err := io.ReadAll(res.Body)
+		requireNil(t, err)
+		_ = res.Body.Close()
+		if string(bs) != want {
+			t.Fatalf("%s: got %d bytes, want %d", key, len(bs), len(want))
+		}
+	}
+
+	for key, code := range map[string]int{"file_code": 234003, "file_invalid": 234001} {
+		_, err := fsApp.GetMessageResource("om_1", key, MessageResourceFile)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != code {
+			t.Fatalf("%s: got %v, want code %d", key, err, code)
+		}
+	}
+}
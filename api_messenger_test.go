@@ -1,6 +1,12 @@
 package feishu
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -143,3 +149,209 @@ func Test_app_ReplyMessage(t *testing.T) {
 
 	logIndent(t, msgDetail)
 }
+
+func Test_splitMessageText(t *testing.T) {
+	receiveID := "oc_5f2c7c2066c6be483bb0302f2fa0c04f"
+
+	checkChunks := func(t *testing.T, chunks []string) {
+		t.Helper()
+		for i, chunk := range chunks {
+			content, err := NewMessageText(chunk).marshalContent()
+			requireNil(t, err)
+			data := &sendMessageRequest{ReceiveID: receiveID, Content: content, MsgType: "text"}
+			if err = data.checkSize(); err != nil {
+				t.Fatalf("chunk %d: %s", i, err)
+			}
+		}
+	}
+
+	t.Run("lines", func(t *testing.T) {
+		lines := make([]string, 0, 20000)
+		for i := 0; i < cap(lines); i++ {
+			lines = append(lines, fmt.Sprintf(`<%d> "日志" line`, i))
+		}
+		lines[100] = ""
+		text := strings.Join(lines, "\n")
+
+		data := &sendMessageRequest{ReceiveID: receiveID, MsgType: "text"}
+		data.Content, _ = NewMessageText(text).marshalContent()
+		var errTooLarge *MessageTooLargeError
+		if err := data.checkSize(); !errors.As(err, &errTooLarge) {
+			t.Fatalf("expected *MessageTooLargeError, got %v", err)
+		}
+
+		chunks, err := splitMessageText(receiveID, text)
+		requireNil(t, err)
+		if len(chunks) < 2 {
+			t.Fatalf("expected multiple chunks, got %d", len(chunks))
+		}
+		checkChunks(t, chunks)
+		if got := strings.Join(chunks, "\n"); got != text {
+			t.Fatal("joined chunks differ from the original text")
+		}
+	})
+
+	t.Run("long line", func(t *testing.T) {
+		text := strings.Repeat("飞", 100000)
+		chunks, err := splitMessageText(receiveID, text)
+		requireNil(t, err)
+		checkChunks(t, chunks)
+		if got := strings.Join(chunks, ""); got != text {
+			t.Fatal("joined chunks differ from the original text")
+		}
+	})
+}
+
+func TestNewMessageCardTemplate(t *testing.T) {
+	type vars struct {
+		Service string   `json:"service"`
+		Hosts   []string `json:"hosts"`
+	}
+
+	tests := []struct {
+		name      string
+		msg       *Message
+		want      string
+		wantError bool
+	}{
+		{
+			name: "struct",
+			msg:  NewMessageCardTemplate("AAqk1234", "1.0.2", &vars{Service: "api", Hosts: []string{"a", "b"}}),
+			want: `{"type":"template","data":{"template_id":"AAqk1234","template_version_name":"1.0.2","template_variable":{"service":"api","hosts":["a","b"]}}}`,
+		},
+		{
+			name: "map",
+			msg:  NewMessageCardTemplate("AAqk1234", "", map[string]interface{}{"count": 3}),
+			want: `{"type":"template","data":{"template_id":"AAqk1234","template_variable":{"count":3}}}`,
+		},
+		{
+			name: "nil",
+			msg:  NewMessageCardTemplate("AAqk1234", "", nil),
+			want: `{"type":"template","data":{"template_id":"AAqk1234"}}`,
+		},
+		{
+			name:      "slice",
+			msg:       NewMessageCardTemplate("AAqk1234", "", []string{"a"}),
+			wantError: true,
+		},
+		{
+			name:      "empty id",
+			msg:       NewMessageCardTemplate("", "", nil),
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.msg.marshalContent()
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			requireNil(t, err)
+			if got != tt.want {
+				t.Fatalf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPostParagraphs(t *testing.T) {
+	msg := NewMessagePost(
+		WithPostParagraphs(LangChinese, "标题",
+			WithPostParagraph(
+				WithPostElementStyledText("加粗", PostTextBold, PostTextUnderline),
+				WithPostElementStyledLink("链接", "https://www.feishu.cn", PostTextItalic),
+				WithPostElementEmotion(EmojiThumbsUp),
+			),
+			WithPostParagraph(),
+			WithPostParagraph(
+				WithPostElementText("代码:"),
+				WithPostElementCodeBlock("GO", `fmt.Println("hi")`),
+				WithPostElementHorizontalRule(),
+				WithPostElementMarkdown("**md**"),
+				WithPostElementMedia("file_v2_xxx", "img_v2_xxx"),
+			),
+		),
+	)
+	content, err := msg.marshalContent()
+	requireNil(t, err)
+
+	want := `{"zh_cn":{"content":[` +
+		`[{"style":["bold","underline"],"tag":"text","text":"加粗"},{"href":"https://www.feishu.cn","style":["italic"],"tag":"a","text":"链接"},{"emoji_type":"THUMBSUP","tag":"emotion"}],` +
+		`[],` +
+		`[{"tag":"text","text":"代码:"}],` +
+		`[{"language":"GO","tag":"code_block","text":"fmt.Println(\"hi\")"}],` +
+		`[{"tag":"hr"}],` +
+		`[{"tag":"md","text":"**md**"}],` +
+		`[{"file_key":"file_v2_xxx","image_key":"img_v2_xxx","tag":"media"}]` +
+		`],"title":"标题"}}`
+	if content != want {
+		t.Errorf("content\n got: %s\nwant: %s", content, want)
+	}
+
+	c, err := DecodeMessageContent(MsgTypePost, content)
+	requireNil(t, err)
+	post := c.(*MessageContentPost)
+	if got := post.I18n[LangChinese].Content[3][0].Language; got != "GO" {
+		t.Errorf("decoded code_block language = %q", got)
+	}
+}
+
+func Test_app_SendMessage_overflowFile(t *testing.T) {
+	var (
+		texts    []string
+		uploaded []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open-apis/auth/v3/app_access_token/internal":
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"ok","app_access_token":"t-test","expire":7200}`)
+		case "/open-apis/im/v1/messages":
+			req := new(sendMessageRequest)
+			requireNil(t, json.NewDecoder(r.Body).Decode(req))
+			if req.MsgType == "text" {
+				content := make(map[string]string)
+				requireNil(t, json.Unmarshal([]byte(req.Content), &content))
+				texts = append(texts, content["text"])
+			}
+			_, _ = fmt.Fprintf(w, `{"code":0,"msg":"success","data":{"message_id":"om_%d","msg_type":%q}}`, len(texts), req.MsgType)
+		case "/open-apis/im/v1/files":
+			f, _, err := r.FormFile("file")
+			requireNil(t, err)
+			uploaded, err = io.ReadAll(f)
+			requireNil(t, err)
+			_, _ = fmt.Fprint(w, `{"code":0,"msg":"success","data":{"file_key":"file_1"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	fsApp := newApp("cli_test", "secret", WithAppOpenBaseURL(srv.URL))
+	fsApp.isCustomApp = true
+	receiver := MessageReceiver{ID: "oc_1", IDType: ChatID}
+
+	// 单行超出限制, 按字符拆分
+	text := strings.Repeat("飞", 100000) + "\n\ntail"
+	detail, err := fsApp.SendMessage(receiver, NewMessageText(text), WithSendMessageOverflowFile("overflow.txt"))
+	requireNil(t, err)
+	if detail.MessageID != "om_1" || len(texts) != 1 {
+		t.Fatalf("unexpected detail: %+v, texts: %d", detail, len(texts))
+	}
+	if want := text[len(texts[0]):]; string(uploaded) != want {
+		t.Fatalf("uploaded %d bytes, want the original tail of %d bytes", len(uploaded), len(want))
+	}
+
+	texts, uploaded = nil, nil
+	_, err = fsApp.SendMessage(receiver, NewMessageText(strings.Repeat("a", _fileMaxSize+_msgTextMaxSize+1)),
+		WithSendMessageOverflowFile("overflow.txt"))
+	var errTooLarge *MessageTooLargeError
+	if !errors.As(err, &errTooLarge) || errTooLarge.MsgType != "file" {
+		t.Fatalf("got %v, want *MessageTooLargeError", err)
+	}
+	if len(texts) != 0 || uploaded != nil {
+		t.Fatal("nothing should be sent when the overflow file is too large")
+	}
+}
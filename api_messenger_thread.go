@@ -0,0 +1,112 @@
+package feishu
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// 名称: [消息与群组] 获取会话历史消息
+// Func: [api_messenger_thread.go] GetThreadMessages
+//
+// 描述: 获取会话（包括单聊、群组）的历史消息，此处仅用于获取话题内的消息（container_id_type=thread）
+// Info: 需要开启机器人能力
+// Info: 获取消息时，机器人必须在群组中
+// Info: 话题 ID 可通过 MessageDetail.ThreadID, EventMessage.ThreadID 获取
+// Info: 话题群中，每条消息都是一个话题，可通过 WithReplyMessageInThread 在话题内回复
+//
+// Doc: https://open.feishu.cn/document/server-docs/im-v1/message/list
+//
+// 自建应用: true
+// 商店应用: true
+//
+// HTTP URL: /open-apis/im/v1/messages
+// HTTP Method: GET
+//
+// 请求头: Authorization=Bearer {{AppAccessToken}}
+//
+type messagesResponse struct {
+	fsResponse
+	Data MessagesResponse `json:"data"`
+}
+
+type MessagesResponse struct {
+	Items     []MessageDetail `json:"items"`      // 消息列表
+	PageToken string          `json:"page_token"` // 分页标记，当 has_more 为 true 时，会同时返回新的 page_token，否则不返回 page_token
+	HasMore   bool            `json:"has_more"`   // 是否还有更多项
+}
+
+type GetThreadMessagesOption = doOption
+
+// MessageSortType 消息排序方式
+type MessageSortType string
+
+const (
+	MessageSortByCreateTimeAsc  MessageSortType = "ByCreateTimeAsc"  // 按消息创建时间升序排列
+	MessageSortByCreateTimeDesc MessageSortType = "ByCreateTimeDesc" // 按消息创建时间降序排列
+)
+
+// WithGetThreadMessagesSortType 消息排序方式, 默认 MessageSortByCreateTimeAsc
+func WithGetThreadMessagesSortType(sortType MessageSortType) GetThreadMessagesOption {
+	return withDoQueryKV("sort_type", string(sortType))
+}
+
+func WithGetThreadMessagesPageSize(pageSize int) GetThreadMessagesOption {
+	return withDoQueryKV("page_size", strconv.Itoa(pageSize))
+}
+
+func WithGetThreadMessagesPageToken(pageToken string) GetThreadMessagesOption {
+	return withDoQueryKV("page_token", pageToken)
+}
+
+func WithGetThreadMessagesNextPage(lastResp MessagesResponse) GetThreadMessagesOption {
+	if !lastResp.HasMore {
+		return nil
+	}
+	return withDoQueryKV("page_token", lastResp.PageToken)
+}
+
+func (a *app) GetThreadMessages(threadID string, opts ...GetThreadMessagesOption) (MessagesResponse, error) {
+	return a.GetThreadMessagesWithContext(context.Background(), threadID, opts...)
+}
+
+func (a *app) GetThreadMessagesWithContext(ctx context.Context, threadID string, opts ...GetThreadMessagesOption) (MessagesResponse, error) {
+	apiDomain := "消息与群组"
+	apiName := "获取会话历史消息"
+	urlSuffix := "/open-apis/im/v1/messages"
+
+	if !a.isSupported(true, true) {
+		return MessagesResponse{}, fmt.Errorf(_fmtErrNotSupported, apiDomain, apiName)
+	}
+
+	header := map[string]string{
+		"Authorization": "Bearer ",
+	}
+	if accessToken, err := a.getAppAccessTokenWithContext(ctx); err != nil {
+		return MessagesResponse{}, err
+	} else {
+		header["Authorization"] = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	opts = append([]GetThreadMessagesOption{
+		withDoQuery(map[string]string{
+			"container_id_type": "thread",
+			"container_id":      threadID,
+		}),
+	}, opts...)
+	doOpts := a.buildOpts(apiDomain, apiName, header, opts...)
+	reqID, reader, err := a._getWithContext(ctx, urlSuffix, doOpts...)
+	if err != nil {
+		return MessagesResponse{}, err
+	}
+
+	resp := new(messagesResponse)
+	if err = a._decodeResp(apiDomain, apiName, reader, resp); err != nil {
+		return MessagesResponse{}, err
+	}
+
+	if err = resp.check(reqID, apiDomain, apiName); err != nil {
+		return MessagesResponse{}, err
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,32 @@
+package feishu
+
+import (
+	"testing"
+)
+
+func Test_app_GetThreadMessages(t *testing.T) {
+	fsApp := testNewCustomApp()
+	fsApp.opt.debug = true
+
+	msgDetail, err := fsApp.ReplyMessage("om_d85801e64eb4135954cd657d4e11c8df", NewMessageText("ok"), WithReplyMessageInThread(true))
+	requireNil(t, err)
+
+	logIndent(t, msgDetail)
+
+	msgs := make([]MessageDetail, 0, 12)
+	pageSize := 1
+	msgsResp, err := fsApp.GetThreadMessages(msgDetail.ThreadID, WithGetThreadMessagesPageSize(pageSize))
+	requireNil(t, err)
+	msgs = append(msgs, msgsResp.Items...)
+
+	for msgsResp.HasMore {
+		msgsResp, err = fsApp.GetThreadMessages(msgDetail.ThreadID,
+			WithGetThreadMessagesPageSize(pageSize),
+			WithGetThreadMessagesNextPage(msgsResp),
+		)
+		requireNil(t, err)
+		msgs = append(msgs, msgsResp.Items...)
+	}
+
+	logIndent(t, msgs)
+}
@@ -16,13 +16,53 @@ type App interface {
 	GetTenantAccessTokenInternal() (TenantAccessTokenInternal, error)
 	GetTenantAccessTokenInternalWithContext(ctx context.Context) (TenantAccessTokenInternal, error)
 
-	SendMessage(receiver MessageReceiver, msg *Message) (MessageDetail, error)
-	SendMessageWithContext(ctx context.Context, receiver MessageReceiver, msg *Message) (MessageDetail, error)
-	ReplyMessage(messageID string, msg *Message) (MessageDetail, error)
-	ReplyMessageWithContext(ctx context.Context, messageID string, msg *Message) (MessageDetail, error)
-
-	UploadImage(src UploadImageOption) (imageKey string, err error)
-	UploadImageWithContext(ctx context.Context, src UploadImageOption) (imageKey string, err error)
+	SendMessage(receiver MessageReceiver, msg *Message, opts ...SendMessageOption) (MessageDetail, error)
+	SendMessageWithContext(ctx context.Context, receiver MessageReceiver, msg *Message, opts ...SendMessageOption) (MessageDetail, error)
+	SendMessageToMany(receivers []MessageReceiver, msg *Message, opts ...SendMessageToManyOption) (map[MessageReceiver]SendMessageResult, error)
+	SendMessageToManyWithContext(ctx context.Context, receivers []MessageReceiver, msg *Message, opts ...SendMessageToManyOption) (map[MessageReceiver]SendMessageResult, error)
+	ReplyMessage(messageID string, msg *Message, opts ...ReplyMessageOption) (MessageDetail, error)
+	ReplyMessageWithContext(ctx context.Context, messageID string, msg *Message, opts ...ReplyMessageOption) (MessageDetail, error)
+	GetThreadMessages(threadID string, opts ...GetThreadMessagesOption) (MessagesResponse, error)
+	GetThreadMessagesWithContext(ctx context.Context, threadID string, opts ...GetThreadMessagesOption) (MessagesResponse, error)
+	GetMessage(messageID string) ([]MessageDetail, error)
+	GetMessageWithContext(ctx context.Context, messageID string) ([]MessageDetail, error)
+	ForwardMessage(messageID string, receiver MessageReceiver) (MessageDetail, error)
+	ForwardMessageWithContext(ctx context.Context, messageID string, receiver MessageReceiver) (MessageDetail, error)
+	MergeForwardMessage(receiver MessageReceiver, messageIDs []string) (MergeForwardResult, error)
+	MergeForwardMessageWithContext(ctx context.Context, receiver MessageReceiver, messageIDs []string) (MergeForwardResult, error)
+
+	CreateMessageReaction(messageID string, emojiType EmojiType) (MessageReaction, error)
+	CreateMessageReactionWithContext(ctx context.Context, messageID string, emojiType EmojiType) (MessageReaction, error)
+	GetMessageReactions(messageID string, opts ...GetMessageReactionsOption) (MessageReactionsResponse, error)
+	GetMessageReactionsWithContext(ctx context.Context, messageID string, opts ...GetMessageReactionsOption) (MessageReactionsResponse, error)
+	DeleteMessageReaction(messageID, reactionID string) (MessageReaction, error)
+	DeleteMessageReactionWithContext(ctx context.Context, messageID, reactionID string) (MessageReaction, error)
+
+	PinMessage(messageID string) (Pin, error)
+	PinMessageWithContext(ctx context.Context, messageID string) (Pin, error)
+	UnpinMessage(messageID string) error
+	UnpinMessageWithContext(ctx context.Context, messageID string) error
+	GetChatPins(chatID string, opts ...GetChatPinsOption) (ChatPinsResponse, error)
+	GetChatPinsWithContext(ctx context.Context, chatID string, opts ...GetChatPinsOption) (ChatPinsResponse, error)
+
+	SendEphemeralCard(chatID string, user MessageReceiver, card *Message) (messageID string, err error)
+	SendEphemeralCardWithContext(ctx context.Context, chatID string, user MessageReceiver, card *Message) (messageID string, err error)
+	DeleteEphemeralCard(messageID string) error
+	DeleteEphemeralCardWithContext(ctx context.Context, messageID string) error
+	DelayUpdateCard(token string, card *Message, openIDs ...string) error
+	DelayUpdateCardWithContext(ctx context.Context, token string, card *Message, openIDs ...string) error
+
+	UploadImage(src UploadImageOption, opts ...UploadImageOption) (imageKey string, err error)
+	UploadImageWithContext(ctx context.Context, src UploadImageOption, opts ...UploadImageOption) (imageKey string, err error)
+	UploadFile(fileType FileType, src UploadFileOption, opts ...UploadFileOption) (fileKey string, err error)
+	UploadFileWithContext(ctx context.Context, fileType FileType, src UploadFileOption, opts ...UploadFileOption) (fileKey string, err error)
+	DownloadImage(imageKey string) (MessageResource, error)
+	DownloadImageWithContext(ctx context.Context, imageKey string) (MessageResource, error)
+	GetMessageResource(messageID, key string, resourceType MessageResourceType) (MessageResource, error)
+	GetMessageResourceWithContext(ctx context.Context, messageID, key string, resourceType MessageResourceType) (MessageResource, error)
+
+	GetBotInfo() (BotInfo, error)
+	GetBotInfoWithContext(ctx context.Context) (BotInfo, error)
 
 	GetAllGroupChats(opts ...GetAllGroupChatsOption) (GroupChatsResponse, error)
 	GetAllGroupChatsWithContext(ctx context.Context, opts ...GetAllGroupChatsOption) (GroupChatsResponse, error)
@@ -30,6 +70,7 @@ type App interface {
 	ListenEventCallback(w http.ResponseWriter, r *http.Request)
 	RegisterEventCallback(eventType EventType, handler EventHandler)
 	RegisterEventCallbackV1(eventType EventType, handler EventHandlerV1)
+	RegisterCardActionCallback(handler CardActionHandler)
 }
 
 type Logger interface {
@@ -58,6 +99,8 @@ type app struct {
 	tenantAccess   fsToken
 	eventHandler   map[EventType]EventHandler
 	eventHandlerV1 map[EventType]EventHandlerV1
+
+	cardActionHandler CardActionHandler
 }
 
 type AppOption func(*app)
@@ -136,10 +179,27 @@ func (a *app) _postWithContext(ctx context.Context, urlSuffix string, data inter
 	return a._do(ctx, http.MethodPost, a.openBaseURL+urlSuffix, data, opts...)
 }
 
+func (a *app) _deleteWithContext(ctx context.Context, urlSuffix string, opts ...doOption) (reqID string, resp io.Reader, err error) {
+	return a._do(ctx, http.MethodDelete, a.openBaseURL+urlSuffix, nil, opts...)
+}
+
 func (a *app) _doUploadWithContext(ctx context.Context, urlSuffix string, opts ...doOption) (reqID string, resp io.Reader, err error) {
 	return a._do(ctx, http.MethodPost, a.openBaseURL+urlSuffix, nil, opts...)
 }
 
+func (a *app) _getStreamWithContext(ctx context.Context, urlSuffix string, opts ...doOption) (reqID string, resp *http.Response, err error) {
+	reqID, resp, err = _doStreamWithContext(ctx, http.MethodGet, a.openBaseURL+urlSuffix, nil, opts...)
+	if err == nil {
+		return
+	}
+
+	doOpt := _newDoOpt(opts...)
+	if reqID != "" {
+		return reqID, resp, fmt.Errorf(_fmtErrReq, doOpt.apiDomain, doOpt.apiName, reqID, err)
+	}
+	return reqID, resp, fmt.Errorf(_fmtErrNoReqID, doOpt.apiDomain, doOpt.apiName, err)
+}
+
 func (a *app) _do(ctx context.Context, method, rawURL string, data interface{}, opts ...doOption) (reqID string, resp io.Reader, err error) {
 	reqID, resp, err = _doWithContext(ctx, method, rawURL, data, opts...)
 	if err == nil {
@@ -195,3 +255,23 @@ func (a *app) getAppAccessTokenWithContext(ctx context.Context) (accessToken str
 
 	return a.appAccess.get(), nil
 }
+
+func (a *app) getTenantAccessTokenWithContext(ctx context.Context) (accessToken string, err error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if !a.tenantAccess.isEmpty() && a.tenantAccess.notExpired() {
+		return a.tenantAccess.get(), nil
+	}
+
+	if a.isCustomApp {
+		if _, err = a.GetTenantAccessTokenInternalWithContext(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	// if a.isStoreApp {
+	// }
+
+	return a.tenantAccess.get(), nil
+}
@@ -0,0 +1,200 @@
+package feishu
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook 自定义机器人
+//  仅支持 NewMessageText, NewMessagePost, NewMessageCard, NewMessageShareChat, NewMessageImage 构造的消息
+//  Doc: https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+type Webhook interface {
+	Send(msg *Message) error
+	SendWithContext(ctx context.Context, msg *Message) error
+}
+
+var _ Webhook = (*webhook)(nil)
+
+type webhook struct {
+	url    string
+	secret string
+
+	opt struct {
+		logger Logger
+		debug  bool
+	}
+}
+
+type WebhookOption func(*webhook)
+
+// WithWebhookSecret 设置签名校验的密钥
+//  在自定义机器人的安全设置中开启「签名校验」后获得
+func WithWebhookSecret(secret string) WebhookOption {
+	return func(wh *webhook) {
+		wh.secret = secret
+	}
+}
+
+func WithWebhookDebug(b bool) WebhookOption {
+	return func(wh *webhook) {
+		wh.opt.debug = b
+	}
+}
+
+func WithWebhookDebugLogger(logger Logger) WebhookOption {
+	return func(wh *webhook) {
+		wh.opt.logger = logger
+	}
+}
+
+// NewWebhook 自定义机器人
+//  webhookURL: https://open.feishu.cn/open-apis/bot/v2/hook/xxxxxxxxxxxxxxxxx
+func NewWebhook(webhookURL string, opts ...WebhookOption) Webhook {
+	wh := &webhook{url: webhookURL}
+	for _, fn := range opts {
+		if fn == nil {
+			continue
+		}
+		fn(wh)
+	}
+	return wh
+}
+
+// 名称: [自定义机器人] 发送消息
+// Func: [webhook.go] Send
+//
+// 描述: 通过自定义机器人的 webhook 地址向所在群发送消息
+// Info: 自定义机器人的频率控制和普通应用不同，为 100 次/分钟，5 次/秒
+// Info: 请求体大小不能超过 20 KB
+// Info: 开启签名校验时，需要在请求体中携带 timestamp（秒级时间戳）及 sign，时间戳距当前时间不能超过 1 小时
+//
+// Doc: https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+//
+// HTTP URL: webhook 地址
+// HTTP Method: POST
+//
+// 请求头: Content-Type=application/json; charset=utf-8
+//
+type webhookRequest struct {
+	Timestamp string      `json:"timestamp,omitempty"` // 秒级时间戳, 开启签名校验时必填
+	Sign      string      `json:"sign,omitempty"`      // 签名, 开启签名校验时必填
+	MsgType   string      `json:"msg_type"`            // 消息类型: text, post, interactive, share_chat, image
+	Content   interface{} `json:"content,omitempty"`   // 消息内容, 卡片消息时为空
+	Card      interface{} `json:"card,omitempty"`      // 卡片内容, 仅卡片消息
+}
+
+type webhookResponse struct {
+	fsResponse
+
+	// 旧版接口的返回值
+	StatusCode    int    `json:"StatusCode"`
+	StatusMessage string `json:"StatusMessage"`
+}
+
+const _webhookMaxSize = 20 << 10
+
+// webhookSign 签名校验
+//  以 timestamp + "\n" + 密钥 作为签名字符串, 使用 HmacSHA256 计算签名（签名数据为空）, 再进行 Base64 编码
+func webhookSign(secret string, timestamp int64) (string, error) {
+	stringToSign := strconv.FormatInt(timestamp, 10) + "\n" + secret
+	h := hmac.New(sha256.New, []byte(stringToSign))
+	if _, err := h.Write(nil); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+func newWebhookRequest(msg *Message) (*webhookRequest, error) {
+	if msg.err != nil {
+		return nil, msg.err
+	}
+	req := &webhookRequest{MsgType: msg.msgType}
+
+	switch MessageType(msg.msgType) {
+	case MsgTypeText, MsgTypeImage:
+		req.Content = msg.content
+	case MsgTypePost:
+		req.Content = map[string]interface{}{"post": msg.content}
+	case MsgTypeInteractive:
+		req.Card = msg.content
+	case MsgTypeShareChat:
+		content, err := msg.marshalContent()
+		if err != nil {
+			return nil, err
+		}
+		c, err := DecodeMessageContent(MsgTypeShareChat, content)
+		if err != nil {
+			return nil, err
+		}
+		req.Content = map[string]string{"share_chat_id": c.(*MessageContentShareChat).ChatID}
+	default:
+		return nil, fmt.Errorf("unsupported message type: %s", msg.msgType)
+	}
+
+	return req, nil
+}
+
+func (wh *webhook) Send(msg *Message) error {
+	return wh.SendWithContext(context.Background(), msg)
+}
+
+func (wh *webhook) SendWithContext(ctx context.Context, msg *Message) error {
+	apiDomain := "自定义机器人"
+	apiName := "发送消息"
+
+	data, err := newWebhookRequest(msg)
+	if err != nil {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	if wh.secret != "" {
+		ts := time.Now().Unix()
+		if data.Sign, err = webhookSign(wh.secret, ts); err != nil {
+			return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+		}
+		data.Timestamp = strconv.FormatInt(ts, 10)
+	}
+
+	if bs, err := json.Marshal(data); err != nil {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	} else if len(bs) > _webhookMaxSize {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, &MessageTooLargeError{MsgType: msg.msgType, Size: len(bs), Limit: _webhookMaxSize})
+	}
+
+	header := map[string]string{
+		"Content-Type": "application/json; charset=utf-8",
+	}
+	doOpts := []doOption{withDoAPIDomain(apiDomain), withDoAPIName(apiName), withDoHeader(header)}
+	if wh.opt.logger != nil {
+		doOpts = append(doOpts, withDoLogger(wh.opt.logger))
+	}
+	if wh.opt.debug {
+		doOpts = append(doOpts, withDoDebug(wh.opt.debug))
+	}
+
+	reqID, reader, err := _doWithContext(ctx, http.MethodPost, wh.url, data, doOpts...)
+	if err != nil {
+		if reqID != "" {
+			return fmt.Errorf(_fmtErrReq, apiDomain, apiName, reqID, err)
+		}
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	resp := new(webhookResponse)
+	if err = json.NewDecoder(reader).Decode(resp); err != nil {
+		return fmt.Errorf(_fmtErrNoReqID, apiDomain, apiName, err)
+	}
+
+	if resp.Code == 0 && resp.StatusCode != 0 {
+		resp.Code, resp.Msg = resp.StatusCode, resp.StatusMessage
+	}
+
+	return resp.check(reqID, apiDomain, apiName)
+}
@@ -0,0 +1,85 @@
+package feishu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestWebhook_Send(t *testing.T) {
+	secret := "demo-secret"
+
+	var last map[string]json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = make(map[string]json.RawMessage)
+		requireNil(t, json.NewDecoder(r.Body).Decode(&last))
+
+		var ts, sign string
+		_ = json.Unmarshal(last["timestamp"], &ts)
+		_ = json.Unmarshal(last["sign"], &sign)
+		n, _ := strconv.ParseInt(ts, 10, 64)
+		if want, _ := webhookSign(secret, n); sign != want {
+			_, _ = fmt.Fprint(w, `{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time","data":{}}`)
+			return
+		}
+		if string(last["msg_type"]) == `"image"` {
+			// 旧版接口的返回值
+			_, _ = fmt.Fprint(w, `{"StatusCode":0,"StatusMessage":"success"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"code":0,"msg":"success","data":{}}`)
+	}))
+	defer srv.Close()
+
+	wh := NewWebhook(srv.URL, WithWebhookSecret(secret))
+
+	tests := []struct {
+		msg   *Message
+		key   string
+		check func(raw json.RawMessage) bool
+	}{
+		{NewMessageText("hello"), "content", func(raw json.RawMessage) bool {
+			return string(raw) == `{"text":"hello"}`
+		}},
+		{NewMessagePost(WithPost(LangChinese, "标题", WithPostElementText("内容"))), "content", func(raw json.RawMessage) bool {
+			var v struct {
+				Post map[string]struct {
+					Title string `json:"title"`
+				} `json:"post"`
+			}
+			return json.Unmarshal(raw, &v) == nil && v.Post["zh_cn"].Title == "标题"
+		}},
+		{NewMessageCard(BgColorGreen, nil, WithCard(LangChinese, "标题", WithCardElementMarkdown("**ok**"))), "card", func(raw json.RawMessage) bool {
+			var v map[string]interface{}
+			return json.Unmarshal(raw, &v) == nil && v["header"] != nil && v["i18n_elements"] != nil
+		}},
+		{NewMessageShareChat("oc_1"), "content", func(raw json.RawMessage) bool {
+			return string(raw) == `{"share_chat_id":"oc_1"}`
+		}},
+		{NewMessageImage("img_1"), "content", func(raw json.RawMessage) bool {
+			return string(raw) == `{"image_key":"img_1"}`
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg.msgType, func(t *testing.T) {
+			requireNil(t, wh.Send(tt.msg))
+			if !tt.check(last[tt.key]) {
+				t.Fatalf("unexpected %s: %s", tt.key, last[tt.key])
+			}
+		})
+	}
+
+	var apiErr *APIError
+	err := NewWebhook(srv.URL, WithWebhookSecret("wrong")).Send(NewMessageText("hello"))
+	if !errors.As(err, &apiErr) || apiErr.Code != 19021 {
+		t.Fatalf("expected sign error, got %v", err)
+	}
+
+	if err = wh.Send(NewMessageFile("file_1")); err == nil {
+		t.Fatal("expected unsupported message type error")
+	}
+}
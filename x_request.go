@@ -91,6 +91,19 @@ func withDoUploadFormData(name string, value io.Reader) doOption {
 	}
 }
 
+// withDoUploadFormValue 与 withDoUploadFormData 相同, 但会替换已存在的同名表单项
+func withDoUploadFormValue(name, value string) doOption {
+	return func(opt *_doOpt) {
+		for i, fd := range opt.uploadFormData {
+			if fd.name == name {
+				opt.uploadFormData[i] = newDoFormData(name, strings.NewReader(value))
+				return
+			}
+		}
+		withDoUploadFormData(name, strings.NewReader(value))(opt)
+	}
+}
+
 func withDoUploadFormFile(name, filename string, needToReadFile bool, data io.Reader) doOption {
 	return func(opt *_doOpt) {
 		if opt.uploadFormData == nil {
@@ -227,11 +240,7 @@ func _doWithContext(ctx context.Context, method, rawURL string, data interface{}
 		return "", nil, err
 	}
 
-	tmpCli := DefaultHTTPClient
-	if doOpt.httpCli != nil {
-		tmpCli = doOpt.httpCli
-	}
-	tmpCli.Timeout = 0
+	tmpCli := _noTimeoutClient(doOpt.httpCli)
 
 	start := time.Now()
 
@@ -255,7 +264,46 @@ func _doWithContext(ctx context.Context, method, rawURL string, data interface{}
 	return reqID, respBuf, nil
 }
 
+// _doStreamWithContext 与 _doWithContext 相同, 但不读取响应体, 由调用方负责关闭 resp.Body
+func _doStreamWithContext(ctx context.Context, method, rawURL string, data interface{}, opts ...doOption) (reqID string, resp *http.Response, err error) {
+	doOpt := _newDoOpt(opts...)
+
+	var req *http.Request
+	if req, err = doOpt.NewRequest(ctx, method, rawURL, data); err != nil {
+		return "", nil, err
+	}
+
+	tmpCli := _noTimeoutClient(doOpt.httpCli)
+
+	start := time.Now()
+
+	if resp, err = tmpCli.Do(req); err != nil {
+		return "", nil, err
+	}
+
+	reqID = resp.Header.Get("X-Request-Id")
+
+	doOpt.debugLog(fmt.Sprintf("<-- [%s - %s] %s %s %d %s\nContent-Type: %s\nContent-Length: %d\n", doOpt.apiDomain, doOpt.apiName, method, rawURL, resp.StatusCode, time.Since(start), resp.Header.Get("Content-Type"), resp.ContentLength))
+
+	return reqID, resp, nil
+}
+
+// _noTimeoutClient 超时由 context 控制
+//  复制一份 http.Client, 避免并发请求时修改共享的 Timeout
+func _noTimeoutClient(cli *http.Client) *http.Client {
+	if cli == nil {
+		cli = DefaultHTTPClient
+	}
+	if cli.Timeout == 0 {
+		return cli
+	}
+	tmp := *cli
+	tmp.Timeout = 0
+	return &tmp
+}
+
 type _doFormData struct {
+	name           string
 	headerValue    string
 	data           io.Reader
 	needToReadFile bool
@@ -264,6 +312,7 @@ type _doFormData struct {
 
 func newDoFormData(name string, data io.Reader) *_doFormData {
 	return &_doFormData{
+		name:           name,
 		headerValue:    fmt.Sprintf(`form-data; name="%s"`, name),
 		data:           data,
 		needToReadFile: false,
@@ -272,6 +321,7 @@ func newDoFormData(name string, data io.Reader) *_doFormData {
 
 func newDoFormDataWithFile(name, filename string, needToReadFile bool, data io.Reader) *_doFormData {
 	return &_doFormData{
+		name:           name,
 		headerValue:    fmt.Sprintf(`form-data; name="%s"; filename="%s"`, name, escapeQuotes(path.Base(filename))),
 		data:           data,
 		needToReadFile: needToReadFile,